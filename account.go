@@ -0,0 +1,52 @@
+package main
+
+import "fmt"
+
+const maxTemplatePageSize = 500
+
+// ServerTemplate is a template listed together with the server it lives on
+type ServerTemplate struct {
+	ServerID   int64
+	ServerName string
+	PostmarkTemplateDetails
+}
+
+// GetTemplatesAcrossServers lists every template on each server whose token is given.
+// opts are applied to the client created for each server.
+func GetTemplatesAcrossServers(serverTokens []string, opts ...Option) ([]ServerTemplate, error) {
+	var serverTemplates []ServerTemplate
+	for _, token := range serverTokens {
+		client := NewClient(token, opts...)
+		server, err := client.GetServer()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get server: %w", err)
+		}
+
+		templates, err := client.getAllTemplates()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list templates for server %d: %w", server.ID, err)
+		}
+		for _, template := range templates {
+			serverTemplates = append(serverTemplates, ServerTemplate{
+				ServerID:                server.ID,
+				ServerName:              server.Name,
+				PostmarkTemplateDetails: template,
+			})
+		}
+	}
+	return serverTemplates, nil
+}
+
+func (c *Client) getAllTemplates() ([]PostmarkTemplateDetails, error) {
+	var templates []PostmarkTemplateDetails
+	for offset := 0; ; offset += maxTemplatePageSize {
+		page, err := c.GetTemplates(offset, maxTemplatePageSize)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, page...)
+		if len(page) < maxTemplatePageSize {
+			return templates, nil
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// Attachment represents a file attached to an outgoing email
+type Attachment struct {
+	Name        string `json:"Name"`
+	Content     string `json:"Content"`
+	ContentType string `json:"ContentType"`
+	ContentID   string `json:"ContentID,omitempty"`
+}
+
+// NewAttachment base64-encodes data as an attachment. When contentType is empty it is
+// inferred from the file extension, falling back to sniffing the content.
+func NewAttachment(name string, data []byte, contentType string) Attachment {
+	if contentType == "" {
+		contentType = detectContentType(name, data)
+	}
+	return Attachment{
+		Name:        name,
+		Content:     base64.StdEncoding.EncodeToString(data),
+		ContentType: contentType,
+	}
+}
+
+func detectContentType(name string, data []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(data)
+}
+
+// NewAttachmentFromReader streams r through a base64 encoder, so the raw file is never
+// held in memory alongside its encoding. contentType is inferred as in NewAttachment.
+func NewAttachmentFromReader(name string, r io.Reader, contentType string) (Attachment, error) {
+	var content strings.Builder
+	encoder := base64.NewEncoder(base64.StdEncoding, &content)
+
+	if contentType == "" {
+		head := make([]byte, 512)
+		n, err := io.ReadFull(r, head)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return Attachment{}, fmt.Errorf("failed to read attachment %s: %w", name, err)
+		}
+		contentType = detectContentType(name, head[:n])
+		encoder.Write(head[:n])
+	}
+
+	if _, err := io.Copy(encoder, r); err != nil {
+		return Attachment{}, fmt.Errorf("failed to read attachment %s: %w", name, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return Attachment{}, fmt.Errorf("failed to encode attachment %s: %w", name, err)
+	}
+
+	return Attachment{
+		Name:        name,
+		Content:     content.String(),
+		ContentType: contentType,
+	}, nil
+}
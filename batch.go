@@ -0,0 +1,342 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+)
+
+const (
+	maxBatchSize            = 500
+	maxRecipientsPerMessage = 50
+	defaultMaxBatchBodySize = 50 * 1024 * 1024
+)
+
+// BatchFailure describes a single message that Postmark rejected within a batch
+type BatchFailure struct {
+	Index     int
+	ErrorCode int
+	Message   string
+}
+
+// BatchError is returned when one or more messages in a batch failed
+type BatchError struct {
+	Total    int
+	Failures []BatchFailure
+}
+
+func (e *BatchError) Error() string {
+	details := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		details = append(details, fmt.Sprintf("#%d: %d %s", failure.Index, failure.ErrorCode, failure.Message))
+	}
+	return fmt.Sprintf("batch send failed for %d of %d messages: %s", len(e.Failures), e.Total, strings.Join(details, "; "))
+}
+
+// Unwrap exposes each failure as an *APIError, so errors.Is(err, ErrInactiveRecipient)
+// reports whether any message in the batch went to an inactive recipient
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = &APIError{ErrorCode: failure.ErrorCode, Message: failure.Message}
+	}
+	return errs
+}
+
+// SendEmailBatch sends up to 500 emails in one request. Each message is sent with its
+// own From, so one batch can mix sender identities. The returned results always
+// line up with the input slice; if any message failed a *BatchError lists them by index.
+func (c *Client) SendEmailBatch(emails []EmailRequest) ([]EmailResponse, error) {
+	prepared := make([]EmailRequest, len(emails))
+	for i, email := range emails {
+		prepared[i] = c.prepareEmail(email)
+		if err := c.checkEmail(prepared[i]); err != nil {
+			return nil, fmt.Errorf("message #%d: %w", i, err)
+		}
+	}
+
+	emailResponses := make([]EmailResponse, len(prepared))
+	toSend, sendIndex, err := c.suppressRecipients(prepared, emailResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(toSend) > 0 {
+		// The batch is one request, so it waits with its most urgent message's priority.
+		priority := toSend[0].Priority
+		for _, email := range toSend[1:] {
+			priority = max(priority, email.Priority)
+		}
+
+		var sentResponses []EmailResponse
+		if err := c.doRequest("POST", "/email/batch", toSend, &sentResponses, withPriority(priority)); err != nil {
+			return nil, err
+		}
+		sentResponses = withMissingResults(sentResponses, len(toSend))
+		for i, sentResponse := range sentResponses {
+			emailResponses[sendIndex[i]] = sentResponse
+		}
+	}
+
+	for i := range emailResponses {
+		emailResponses[i].Metadata = prepared[i].Metadata
+		emailResponses[i].Warnings = c.emailWarnings(prepared[i])
+		if emailResponses[i].To == "" {
+			emailResponses[i].To = prepared[i].To
+		}
+	}
+
+	return emailResponses, batchError(emailResponses)
+}
+
+// SendIndividually sends a separate copy of email to each recipient, so no recipient
+// sees the others' addresses. The copies go out through the batch endpoint, in as many
+// batches as needed; any To, Cc and Bcc on email are dropped. The results line up with
+// recipients.
+func (c *Client) SendIndividually(email EmailRequest, recipients []string) ([]EmailResponse, error) {
+	email.Cc = ""
+	email.Bcc = ""
+	emails := make([]EmailRequest, len(recipients))
+	for i, recipient := range recipients {
+		emails[i] = email
+		emails[i].To = recipient
+	}
+
+	emailResponses := make([]EmailResponse, 0, len(emails))
+	for start := 0; start < len(emails); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(emails))
+		batchResponses, err := c.SendEmailBatch(emails[start:end])
+		if err != nil && batchResponses == nil {
+			return emailResponses, err
+		}
+		emailResponses = append(emailResponses, batchResponses...)
+	}
+	return emailResponses, batchError(emailResponses)
+}
+
+// suppressRecipients removes suppressed recipients when WithAutoSuppress is enabled. It
+// returns the messages still to send and, for each, its index in emails. Messages left
+// with no recipients are marked Suppressed in emailResponses instead of being sent.
+func (c *Client) suppressRecipients(emails []EmailRequest, emailResponses []EmailResponse) ([]EmailRequest, []int, error) {
+	var suppressed map[string]bool
+	if c.autoSuppress != nil {
+		var err error
+		if suppressed, err = c.autoSuppress.load(c); err != nil {
+			return nil, nil, fmt.Errorf("failed to load suppressions: %w", err)
+		}
+	}
+
+	toSend := make([]EmailRequest, 0, len(emails))
+	sendIndex := make([]int, 0, len(emails))
+	for i, email := range emails {
+		if suppressed != nil && c.autoSuppress.appliesTo(email) {
+			original := email.To
+			email.To = withoutSuppressed(email.To, suppressed)
+			email.Cc = withoutSuppressed(email.Cc, suppressed)
+			email.Bcc = withoutSuppressed(email.Bcc, suppressed)
+			if email.To == "" && email.Cc == "" && email.Bcc == "" {
+				emailResponses[i] = EmailResponse{To: original, Message: "Not sent: all recipients are suppressed", Suppressed: true}
+				continue
+			}
+		}
+		toSend = append(toSend, email)
+		sendIndex = append(sendIndex, i)
+	}
+	return toSend, sendIndex, nil
+}
+
+// SendEmailBatchAtomic sends a batch that should succeed or fail as a unit. Every
+// message passes the client's pre-send checks before any is sent, but Postmark cannot
+// recall messages it has accepted, so when some messages still fail the returned
+// error wraps ErrBatchIncomplete and states how many were delivered regardless.
+// The error also wraps the *BatchError naming the failed messages.
+func (c *Client) SendEmailBatchAtomic(emails []EmailRequest) ([]EmailResponse, error) {
+	emailResponses, err := c.SendEmailBatch(emails)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		return emailResponses, err
+	}
+
+	accepted := SummarizeBatch(emailResponses).Succeeded
+	return emailResponses, fmt.Errorf("%w: %d of %d messages failed and %d were already accepted: %w",
+		ErrBatchIncomplete, len(batchErr.Failures), batchErr.Total, accepted, batchErr)
+}
+
+// batchError returns a *BatchError listing the failed entries of a batch, or nil.
+// withMissingResults makes responses exactly n long. Results Postmark did not return
+// are marked failed, so a short response is never mistaken for success.
+func withMissingResults(responses []EmailResponse, n int) []EmailResponse {
+	if len(responses) > n {
+		return responses[:n]
+	}
+	for len(responses) < n {
+		responses = append(responses, EmailResponse{
+			ErrorCode: errorCodeMissingResult,
+			Message:   "Postmark returned no result for this message",
+		})
+	}
+	return responses
+}
+
+func batchError(emailResponses []EmailResponse) error {
+	var failures []BatchFailure
+	for i, emailResponse := range emailResponses {
+		if emailResponse.ErrorCode != 0 {
+			failures = append(failures, BatchFailure{
+				Index:     i,
+				ErrorCode: emailResponse.ErrorCode,
+				Message:   emailResponse.Message,
+			})
+		}
+	}
+	if len(failures) > 0 {
+		return &BatchError{Total: len(emailResponses), Failures: failures}
+	}
+	return nil
+}
+
+// BatchSummary counts the outcomes of a sent batch. Messages not sent because every
+// recipient is suppressed are counted as Suppressed, not as Succeeded.
+type BatchSummary struct {
+	Succeeded   int
+	Failed      int
+	Suppressed  int
+	ByErrorCode map[int]int
+}
+
+// SummarizeBatch tallies the results returned by SendEmailBatch or SendTemplatedEmailBatch
+func SummarizeBatch(emailResponses []EmailResponse) BatchSummary {
+	summary := BatchSummary{ByErrorCode: make(map[int]int)}
+	for _, emailResponse := range emailResponses {
+		if emailResponse.Suppressed {
+			summary.Suppressed++
+			continue
+		}
+		if emailResponse.ErrorCode == 0 {
+			summary.Succeeded++
+			continue
+		}
+		summary.Failed++
+		summary.ByErrorCode[emailResponse.ErrorCode]++
+	}
+	return summary
+}
+
+func (s BatchSummary) String() string {
+	codes := make([]int, 0, len(s.ByErrorCode))
+	for code := range s.ByErrorCode {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	details := make([]string, 0, len(codes))
+	for _, code := range codes {
+		details = append(details, fmt.Sprintf("%d=%d", code, s.ByErrorCode[code]))
+	}
+	return fmt.Sprintf("succeeded=%d failed=%d suppressed=%d errors=[%s]", s.Succeeded, s.Failed, s.Suppressed, strings.Join(details, " "))
+}
+
+// InactiveRecipient is a batch entry rejected because its recipient is inactive
+type InactiveRecipient struct {
+	Index   int
+	To      string
+	Message string
+}
+
+// InactiveRecipients extracts the entries of a batch result that Postmark rejected with
+// error code 406, for feeding into suppression cleanup
+func InactiveRecipients(emailResponses []EmailResponse) []InactiveRecipient {
+	var inactive []InactiveRecipient
+	for i, emailResponse := range emailResponses {
+		if emailResponse.ErrorCode == errorCodeInactiveRecipient {
+			inactive = append(inactive, InactiveRecipient{Index: i, To: emailResponse.To, Message: emailResponse.Message})
+		}
+	}
+	return inactive
+}
+
+// BatchStats summarizes a batch before it is sent
+type BatchStats struct {
+	Messages            int
+	UniqueRecipients    int
+	TotalBytes          int
+	LargestMessageBytes int
+	ExceedsLimits       bool
+	Problems            []string
+}
+
+// InspectBatch reports the size of a batch as SendEmailBatch would serialize it and
+// whether it breaks any of Postmark's batch limits. Nothing is sent, and the from pool
+// is only peeked at so that inspecting a batch does not change who sends the next one.
+func (c *Client) InspectBatch(emails []EmailRequest) (BatchStats, error) {
+	prepared := make([]EmailRequest, len(emails))
+	recipients := make(map[string]struct{})
+	stats := BatchStats{Messages: len(emails)}
+
+	pooled := 0
+	for i, email := range emails {
+		if email.From == "" && c.fromPool != nil {
+			email.From = c.fromPool.peek(pooled)
+			pooled++
+		}
+		prepared[i] = c.prepareEmail(email)
+
+		data, err := c.codec.Marshal(prepared[i])
+		if err != nil {
+			return BatchStats{}, fmt.Errorf("failed to marshal message #%d: %w", i, err)
+		}
+		stats.LargestMessageBytes = max(stats.LargestMessageBytes, len(data))
+		if c.maxBodySize > 0 && len(data) > c.maxBodySize {
+			stats.Problems = append(stats.Problems, fmt.Sprintf("message #%d is %d bytes, over the %d byte limit", i, len(data), c.maxBodySize))
+		}
+
+		addresses := splitAddresses(email.To, email.Cc, email.Bcc)
+		if len(addresses) > maxRecipientsPerMessage {
+			stats.Problems = append(stats.Problems, fmt.Sprintf("message #%d has %d recipients, over the %d recipient limit", i, len(addresses), maxRecipientsPerMessage))
+		}
+		for _, address := range addresses {
+			recipients[address] = struct{}{}
+		}
+	}
+
+	data, err := c.codec.Marshal(prepared)
+	if err != nil {
+		return BatchStats{}, fmt.Errorf("failed to marshal batch: %w", err)
+	}
+	stats.TotalBytes = len(data)
+	stats.UniqueRecipients = len(recipients)
+
+	if stats.Messages > maxBatchSize {
+		stats.Problems = append(stats.Problems, fmt.Sprintf("batch has %d messages, over the %d message limit", stats.Messages, maxBatchSize))
+	}
+	if limit := c.bodySizeLimit("/email/batch"); limit > 0 && stats.TotalBytes > limit {
+		stats.Problems = append(stats.Problems, fmt.Sprintf("batch is %d bytes, over the %d byte limit", stats.TotalBytes, limit))
+	}
+	stats.ExceedsLimits = len(stats.Problems) > 0
+
+	return stats, nil
+}
+
+// splitAddresses returns the normalized addresses in comma-separated recipient lists.
+func splitAddresses(lists ...string) []string {
+	var addresses []string
+	for _, list := range lists {
+		if list == "" {
+			continue
+		}
+		if parsed, err := mail.ParseAddressList(list); err == nil {
+			for _, address := range parsed {
+				addresses = append(addresses, strings.ToLower(address.Address))
+			}
+			continue
+		}
+		for _, entry := range strings.Split(list, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				addresses = append(addresses, strings.ToLower(entry))
+			}
+		}
+	}
+	return addresses
+}
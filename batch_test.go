@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendEmailBatchMissingResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"MessageID":"a","To":"one@example.com","ErrorCode":0,"Message":"OK"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	emailResponses, err := client.SendEmailBatch([]EmailRequest{
+		{From: "sender@example.com", To: "one@example.com", Subject: "1", TextBody: "1"},
+		{From: "sender@example.com", To: "two@example.com", Subject: "2", TextBody: "2"},
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("err = %v, want *BatchError", err)
+	}
+	if len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 {
+		t.Fatalf("failures = %+v, want only message 1", batchErr.Failures)
+	}
+	if !errors.Is(err, ErrMissingResult) {
+		t.Errorf("err = %v, want it to match ErrMissingResult", err)
+	}
+	if len(emailResponses) != 2 || emailResponses[0].MessageID != "a" || emailResponses[1].To != "two@example.com" {
+		t.Errorf("responses = %+v", emailResponses)
+	}
+}
+
+func TestSendEmailBatchKeepsEachFrom(t *testing.T) {
+	var sent []EmailRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Write([]byte(`[{"ErrorCode":0,"Message":"OK"},{"ErrorCode":0,"Message":"OK"},{"ErrorCode":0,"Message":"OK"}]`))
+	}))
+	defer server.Close()
+
+	froms := []string{"billing@example.com", "Support <support@example.com>", "alerts@example.org"}
+	emails := make([]EmailRequest, len(froms))
+	for i, from := range froms {
+		emails[i] = EmailRequest{From: from, To: "r@example.com", Subject: "s", TextBody: "b"}
+	}
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	if _, err := client.SendEmailBatch(emails); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != len(froms) {
+		t.Fatalf("sent %d messages, want %d", len(sent), len(froms))
+	}
+	for i, email := range sent {
+		if email.From != froms[i] {
+			t.Errorf("message %d From = %q, want %q", i, email.From, froms[i])
+		}
+	}
+}
+
+func TestInspectBatchLeavesFromPoolAlone(t *testing.T) {
+	var sent EmailRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Write([]byte(`{"ErrorCode":0,"Message":"OK"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL), WithFromPool([]string{"a@example.com", "b@example.com"}))
+	email := EmailRequest{To: "r@example.com", Subject: "s", TextBody: "b"}
+	if _, err := client.InspectBatch([]EmailRequest{email, email, email}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SendEmail(email); err != nil {
+		t.Fatal(err)
+	}
+	if sent.From != "a@example.com" {
+		t.Errorf("From after InspectBatch = %q, want a@example.com", sent.From)
+	}
+}
+
+func TestSummarizeBatchCountsSuppressedSeparately(t *testing.T) {
+	summary := SummarizeBatch([]EmailResponse{
+		{MessageID: "a", ErrorCode: 0},
+		{Message: "Not sent: all recipients are suppressed", Suppressed: true},
+		{ErrorCode: 406},
+	})
+	if summary.Succeeded != 1 || summary.Suppressed != 1 || summary.Failed != 1 {
+		t.Errorf("SummarizeBatch = %+v, want 1 succeeded, 1 suppressed and 1 failed", summary)
+	}
+	if got, want := summary.String(), "succeeded=1 failed=1 suppressed=1 errors=[406=1]"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
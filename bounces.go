@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const maxBouncePageSize = 500
+
+// BounceQuery represents the filters for listing bounces.
+// Dates use the YYYY-MM-DD or YYYY-MM-DDThh:mm:ss format.
+type BounceQuery struct {
+	Count         int
+	Offset        int
+	Type          string
+	Inactive      *bool
+	EmailFilter   string
+	Tag           string
+	MessageID     string
+	FromDate      string
+	ToDate        string
+	MessageStream string
+}
+
+// BouncesResponse represents the response from Postmark API for listing bounces
+type BouncesResponse struct {
+	TotalCount int      `json:"TotalCount"`
+	Bounces    []Bounce `json:"Bounces"`
+}
+
+// Bounce represents a single bounce
+type Bounce struct {
+	ID            int64  `json:"ID"`
+	Type          string `json:"Type"`
+	TypeCode      int    `json:"TypeCode"`
+	Name          string `json:"Name"`
+	Tag           string `json:"Tag"`
+	MessageID     string `json:"MessageID"`
+	ServerID      int64  `json:"ServerID"`
+	MessageStream string `json:"MessageStream"`
+	Description   string `json:"Description"`
+	Details       string `json:"Details"`
+	Email         string `json:"Email"`
+	From          string `json:"From"`
+	BouncedAt     string `json:"BouncedAt"`
+	DumpAvailable bool   `json:"DumpAvailable"`
+	Inactive      bool   `json:"Inactive"`
+	CanActivate   bool   `json:"CanActivate"`
+	Subject       string `json:"Subject"`
+}
+
+func (q BounceQuery) values() url.Values {
+	values := url.Values{}
+	values.Set("count", strconv.Itoa(q.Count))
+	values.Set("offset", strconv.Itoa(q.Offset))
+	if q.Type != "" {
+		values.Set("type", q.Type)
+	}
+	if q.Inactive != nil {
+		values.Set("inactive", strconv.FormatBool(*q.Inactive))
+	}
+	if q.EmailFilter != "" {
+		values.Set("emailFilter", q.EmailFilter)
+	}
+	if q.Tag != "" {
+		values.Set("tag", q.Tag)
+	}
+	if q.MessageID != "" {
+		values.Set("messageID", q.MessageID)
+	}
+	if q.FromDate != "" {
+		values.Set("fromdate", q.FromDate)
+	}
+	if q.ToDate != "" {
+		values.Set("todate", q.ToDate)
+	}
+	if q.MessageStream != "" {
+		values.Set("messagestream", q.MessageStream)
+	}
+	return values
+}
+
+func (c *Client) GetBounces(query BounceQuery) ([]Bounce, error) {
+	return c.getBounces(context.Background(), query)
+}
+
+func (c *Client) getBounces(ctx context.Context, query BounceQuery) ([]Bounce, error) {
+	var bouncesResponse BouncesResponse
+	if err := c.doRequestContext(ctx, "GET", "/bounces?"+query.values().Encode(), nil, &bouncesResponse); err != nil {
+		return nil, err
+	}
+	return bouncesResponse.Bounces, nil
+}
+
+// GetBouncesByEmail returns every bounce recorded for the given recipient address
+func (c *Client) GetBouncesByEmail(email string) ([]Bounce, error) {
+	var bounces []Bounce
+	it := c.NewBounceIterator(BounceQuery{EmailFilter: email})
+	for {
+		bounce, ok, err := it.Next(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return bounces, nil
+		}
+		bounces = append(bounces, bounce)
+	}
+}
+
+// BounceActivationResponse represents the response from Postmark API for activating a bounce
+type BounceActivationResponse struct {
+	Message string `json:"Message"`
+	Bounce  Bounce `json:"Bounce"`
+}
+
+func (c *Client) ActivateBounce(ID int64) (Bounce, error) {
+	url := fmt.Sprintf("/bounces/%d/activate", ID)
+	var activationResponse BounceActivationResponse
+	if err := c.doRequest("PUT", url, nil, &activationResponse); err != nil {
+		return Bounce{}, err
+	}
+	return activationResponse.Bounce, nil
+}
+
+// ActivateBouncesByFilter reactivates every inactive bounce matching the query and
+// returns how many were reactivated. Matching bounces are collected before any are
+// activated so that activation does not shift the pages being read.
+func (c *Client) ActivateBouncesByFilter(query BounceQuery) (int, error) {
+	query.Count = 0
+	query.Offset = 0
+
+	var IDs []int64
+	it := c.NewBounceIterator(query)
+	for {
+		bounce, ok, err := it.Next(context.Background())
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		if bounce.Inactive && bounce.CanActivate {
+			IDs = append(IDs, bounce.ID)
+		}
+	}
+
+	for i, ID := range IDs {
+		if _, err := c.ActivateBounce(ID); err != nil {
+			return i, fmt.Errorf("failed to activate bounce %d: %w", ID, err)
+		}
+	}
+	return len(IDs), nil
+}
+
+// GetBounceCountsByTag pages through all bounces with a BounceIterator and counts them
+// per tag. Bounces of untagged messages are counted under "".
+func (c *Client) GetBounceCountsByTag() (map[string]int, error) {
+	counts := make(map[string]int)
+	it := c.NewBounceIterator(BounceQuery{})
+	for {
+		bounce, ok, err := it.Next(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return counts, nil
+		}
+		counts[bounce.Tag]++
+	}
+}
+
+// BounceIterator pages through bounces one page at a time. Like
+// OutboundMessageIterator, it narrows ToDate to carry on past Postmark's 10,000 result
+// search depth.
+// An iterator must not be shared between goroutines.
+type BounceIterator struct {
+	client *Client
+	query  BounceQuery
+	cursor searchCursor
+	page   []Bounce
+	pos    int
+	done   bool
+}
+
+func (c *Client) NewBounceIterator(query BounceQuery) *BounceIterator {
+	if query.Count <= 0 {
+		query.Count = maxBouncePageSize
+	}
+	return &BounceIterator{client: c, query: query}
+}
+
+// Next returns the next bounce, fetching the following page when the current one is exhausted.
+// The boolean is false once there are no more bounces.
+func (it *BounceIterator) Next(ctx context.Context) (Bounce, bool, error) {
+	for it.pos >= len(it.page) {
+		if it.done {
+			return Bounce{}, false, nil
+		}
+		if err := it.cursor.advance(&it.query.ToDate, &it.query.Offset, it.query.Count); err != nil {
+			return Bounce{}, false, err
+		}
+
+		page, err := it.client.getBounces(ctx, it.query)
+		if err != nil {
+			return Bounce{}, false, err
+		}
+
+		it.query.Offset += len(page)
+		if len(page) < it.query.Count {
+			it.done = true
+		}
+		it.page = it.page[:0]
+		it.pos = 0
+		for _, bounce := range page {
+			if !it.cursor.skip(strconv.FormatInt(bounce.ID, 10), bounce.BouncedAt) {
+				it.page = append(it.page, bounce)
+			}
+		}
+	}
+
+	bounce := it.page[it.pos]
+	it.pos++
+	return bounce, true, nil
+}
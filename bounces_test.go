@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBounceIteratorPastSearchDepth(t *testing.T) {
+	const total = 10750
+	server := newBounceServer(t, total)
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	it := client.NewBounceIterator(BounceQuery{})
+	seen := make(map[int64]bool)
+	for {
+		bounce, ok, err := it.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next after %d bounces: %v", len(seen), err)
+		}
+		if !ok {
+			break
+		}
+		if seen[bounce.ID] {
+			t.Fatalf("bounce %d returned twice", bounce.ID)
+		}
+		seen[bounce.ID] = true
+	}
+	if len(seen) != total {
+		t.Fatalf("got %d bounces, want %d", len(seen), total)
+	}
+}
+
+func TestGetBounceCountsByTagPastSearchDepth(t *testing.T) {
+	const total = 10750
+	server := newBounceServer(t, total)
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	counts, err := client.GetBounceCountsByTag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["even"] != total/2 || counts["odd"] != total/2 {
+		t.Errorf("counts = %v, want %d each", counts, total/2)
+	}
+}
+
+func TestGetBouncesByEmailPastSearchDepth(t *testing.T) {
+	const total = 10750
+	server := newBounceServer(t, total)
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	bounces, err := client.GetBouncesByEmail("r@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bounces) != total {
+		t.Errorf("got %d bounces, want %d", len(bounces), total)
+	}
+}
+
+func TestActivateBouncesByFilterPastSearchDepth(t *testing.T) {
+	const total = 10750
+	var activated atomic.Int32
+	search := newBounceHandler(t, total)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && strings.HasSuffix(r.URL.Path, "/activate") {
+			activated.Add(1)
+			fmt.Fprint(w, `{"Message":"OK","Bounce":{}}`)
+			return
+		}
+		search(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	n, err := client.ActivateBouncesByFilter(BounceQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != total/2 || int(activated.Load()) != total/2 {
+		t.Errorf("activated %d (server saw %d), want %d", n, activated.Load(), total/2)
+	}
+}
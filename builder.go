@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EmailBuilder assembles an EmailRequest step by step; Build validates the result
+type EmailBuilder struct {
+	email EmailRequest
+}
+
+func NewEmail() *EmailBuilder {
+	return &EmailBuilder{}
+}
+
+func (b *EmailBuilder) From(from string) *EmailBuilder {
+	b.email.From = from
+	return b
+}
+
+// To adds a recipient; call it repeatedly for several recipients
+func (b *EmailBuilder) To(to string) *EmailBuilder {
+	b.email.To = appendAddress(b.email.To, to)
+	return b
+}
+
+func (b *EmailBuilder) Cc(cc string) *EmailBuilder {
+	b.email.Cc = appendAddress(b.email.Cc, cc)
+	return b
+}
+
+func (b *EmailBuilder) Bcc(bcc string) *EmailBuilder {
+	b.email.Bcc = appendAddress(b.email.Bcc, bcc)
+	return b
+}
+
+func (b *EmailBuilder) ReplyTo(replyTo string) *EmailBuilder {
+	b.email.ReplyTo = replyTo
+	return b
+}
+
+func (b *EmailBuilder) Subject(subject string) *EmailBuilder {
+	b.email.Subject = subject
+	return b
+}
+
+func (b *EmailBuilder) HTML(htmlBody string) *EmailBuilder {
+	b.email.HtmlBody = htmlBody
+	return b
+}
+
+func (b *EmailBuilder) Text(textBody string) *EmailBuilder {
+	b.email.TextBody = textBody
+	return b
+}
+
+func (b *EmailBuilder) Tag(tag string) *EmailBuilder {
+	b.email.Tag = tag
+	return b
+}
+
+func (b *EmailBuilder) Header(name, value string) *EmailBuilder {
+	b.email.Headers = append(b.email.Headers, Header{Name: name, Value: value})
+	return b
+}
+
+func (b *EmailBuilder) Metadata(key, value string) *EmailBuilder {
+	if b.email.Metadata == nil {
+		b.email.Metadata = make(map[string]string)
+	}
+	b.email.Metadata[key] = value
+	return b
+}
+
+func (b *EmailBuilder) Attach(attachment Attachment) *EmailBuilder {
+	b.email.Attachments = append(b.email.Attachments, attachment)
+	return b
+}
+
+// Build returns the assembled email, or an error wrapping ErrInvalidEmail describing
+// every missing field
+func (b *EmailBuilder) Build() (EmailRequest, error) {
+	var problems []string
+	if b.email.From == "" {
+		problems = append(problems, "missing From")
+	}
+	if b.email.To == "" {
+		problems = append(problems, "missing To")
+	}
+	if b.email.Subject == "" {
+		problems = append(problems, "missing Subject")
+	}
+	if b.email.HtmlBody == "" && b.email.TextBody == "" {
+		problems = append(problems, "missing HtmlBody or TextBody")
+	}
+	if len(problems) > 0 {
+		return EmailRequest{}, fmt.Errorf("%w: %s", ErrInvalidEmail, strings.Join(problems, ", "))
+	}
+	return b.email, nil
+}
+
+func appendAddress(list, address string) string {
+	if list == "" {
+		return address
+	}
+	return list + ", " + address
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// templateCache keeps fetched templates for a fixed time, keyed by the ID or alias
+// they were requested with
+type templateCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]templateCacheEntry
+}
+
+type templateCacheEntry struct {
+	template  PostmarkTemplateResponse
+	expiresAt time.Time
+}
+
+func newTemplateCache(ttl time.Duration) *templateCache {
+	return &templateCache{ttl: ttl, entries: make(map[string]templateCacheEntry)}
+}
+
+func (tc *templateCache) get(idOrAlias string) (PostmarkTemplateResponse, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	entry, ok := tc.entries[idOrAlias]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(tc.entries, idOrAlias)
+		return PostmarkTemplateResponse{}, false
+	}
+	return entry.template, true
+}
+
+func (tc *templateCache) set(idOrAlias string, template PostmarkTemplateResponse) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.entries[idOrAlias] = templateCacheEntry{template: template, expiresAt: time.Now().Add(tc.ttl)}
+}
+
+// remove drops every entry for the template known by idOrAlias, whichever key it was cached under.
+func (tc *templateCache) remove(idOrAlias string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	ID, _ := strconv.ParseInt(idOrAlias, 10, 64)
+	if entry, ok := tc.entries[idOrAlias]; ok {
+		ID = entry.template.TemplateID
+	}
+	for key, entry := range tc.entries {
+		if key == idOrAlias || (ID != 0 && entry.template.TemplateID == ID) {
+			delete(tc.entries, key)
+		}
+	}
+}
+
+// forgetTemplate discards anything cached about a template after it changes.
+func (c *Client) forgetTemplate(idOrAlias string) {
+	if c.templateCache != nil {
+		c.templateCache.remove(idOrAlias)
+	}
+
+	ID, _ := strconv.ParseInt(idOrAlias, 10, 64)
+	c.aliasIDs.Range(func(alias, aliasID interface{}) bool {
+		if alias == idOrAlias || aliasID == ID {
+			c.aliasIDs.Delete(alias)
+		}
+		return true
+	})
+}
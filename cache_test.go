@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResolveTemplateAliasExpiresWithTemplateCache(t *testing.T) {
+	var templateID atomic.Int64
+	templateID.Store(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"TemplateId":%d,"Alias":"welcome"}`, templateID.Load())
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL), WithTemplateCache(10*time.Millisecond))
+	if ID, err := client.ResolveTemplateAlias("welcome"); err != nil || ID != 1 {
+		t.Fatalf("ResolveTemplateAlias = %d, %v, want 1", ID, err)
+	}
+
+	// The alias is deleted and recreated outside the client.
+	templateID.Store(2)
+	time.Sleep(20 * time.Millisecond)
+	if ID, err := client.ResolveTemplateAlias("welcome"); err != nil || ID != 2 {
+		t.Fatalf("ResolveTemplateAlias after ttl = %d, %v, want 2", ID, err)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import "strings"
+
+// categorySeparator splits a template alias into its category and name, as in
+// "billing.invoice".
+const categorySeparator = "."
+
+// TemplateCategory returns the category encoded in an alias: everything before the
+// first separator, or "" when the alias has none.
+func TemplateCategory(alias string) string {
+	category, _, found := strings.Cut(alias, categorySeparator)
+	if !found {
+		return ""
+	}
+	return category
+}
+
+// GetTemplatesByCategory returns every template whose alias starts with prefix followed
+// by a separator. prefix may itself be nested, such as "billing.invoices".
+func (c *Client) GetTemplatesByCategory(prefix string) ([]PostmarkTemplateDetails, error) {
+	templates, err := c.getAllTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.TrimSuffix(prefix, categorySeparator) + categorySeparator
+	var matched []PostmarkTemplateDetails
+	for _, template := range templates {
+		if strings.HasPrefix(template.Alias, prefix) {
+			matched = append(matched, template)
+		}
+	}
+	return matched, nil
+}
+
+// GroupTemplatesByCategory groups templates by TemplateCategory. Templates without a
+// category are grouped under "".
+func GroupTemplatesByCategory(templates []PostmarkTemplateDetails) map[string][]PostmarkTemplateDetails {
+	groups := make(map[string][]PostmarkTemplateDetails)
+	for _, template := range templates {
+		category := TemplateCategory(template.Alias)
+		groups[category] = append(groups[category], template)
+	}
+	return groups
+}
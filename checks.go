@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+)
+
+// checkEmail runs the client's pre-send checks on a prepared email.
+func (c *Client) checkEmail(email EmailRequest) error {
+	if err := checkHeaderFields("From", email.From, "ReplyTo", email.ReplyTo, "Subject", email.Subject); err != nil {
+		return err
+	}
+	if err := checkUTF8("From", email.From, "To", email.To, "Cc", email.Cc, "Bcc", email.Bcc,
+		"Subject", email.Subject, "HtmlBody", email.HtmlBody, "TextBody", email.TextBody); err != nil {
+		return err
+	}
+	if err := c.checkFrom(email.From); err != nil {
+		return err
+	}
+	if err := c.checkSubject(email.Subject); err != nil {
+		return err
+	}
+	return c.checkStream(email)
+}
+
+// checkTemplatedEmail runs the client's pre-send checks on a prepared templated email.
+// The template body is not available here, so only the stream type itself is checked.
+func (c *Client) checkTemplatedEmail(email TemplatedEmailRequest) error {
+	if err := checkHeaderFields("From", email.From); err != nil {
+		return err
+	}
+	if err := c.checkFrom(email.From); err != nil {
+		return err
+	}
+	if c.streamType(email.MessageStream) == MessageStreamInbound {
+		return fmt.Errorf("%w: cannot send on inbound stream %q", ErrStreamContentMismatch, email.MessageStream)
+	}
+	return nil
+}
+
+// checkHeaderFields rejects header values containing CR, LF or other control characters,
+// which could otherwise be used to inject extra headers. fields alternates names and values.
+func checkHeaderFields(fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		name, value := fields[i], fields[i+1]
+		if j := strings.IndexFunc(value, isControl); j >= 0 {
+			return fmt.Errorf("%w: %s contains control character %q", ErrHeaderInjection, name, value[j])
+		}
+	}
+	return nil
+}
+
+func isControl(r rune) bool {
+	return r < 0x20 || r == 0x7f
+}
+
+// checkUTF8 rejects text that is not valid UTF-8, such as a subject decoded from
+// Shift JIS as if it were UTF-8, which would otherwise be sent with its invalid bytes
+// replaced by U+FFFD. fields alternates names and values.
+func checkUTF8(fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if !utf8.ValidString(fields[i+1]) {
+			return fmt.Errorf("%w: %s is not valid UTF-8", ErrInvalidEncoding, fields[i])
+		}
+	}
+	return nil
+}
+
+func (c *Client) checkSubject(subject string) error {
+	if len(subject) > c.maxSubjectLength {
+		return fmt.Errorf("%w: %d octets exceeds limit of %d", ErrSubjectTooLong, len(subject), c.maxSubjectLength)
+	}
+	return nil
+}
+
+// emailWarnings returns the problems with a prepared email that are reported in
+// EmailResponse.Warnings rather than stopping the send.
+func (c *Client) emailWarnings(email EmailRequest) []string {
+	var warnings []string
+	if c.subjectWarnLength > 0 && len(email.Subject) > c.subjectWarnLength {
+		warnings = append(warnings, fmt.Sprintf("subject is %d octets; mail clients may cut it off after %d",
+			len(email.Subject), c.subjectWarnLength))
+	}
+	return warnings
+}
+
+// TruncateSubject shortens subject to at most max octets, ending it with an ellipsis
+// when anything was cut. It never splits a UTF-8 character. A max of zero or less
+// yields an empty subject.
+func TruncateSubject(subject string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+	if len(subject) <= max {
+		return subject
+	}
+	const ellipsis = "…"
+	if max < len(ellipsis) {
+		return cutUTF8(subject, max)
+	}
+	return strings.TrimRight(cutUTF8(subject, max-len(ellipsis)), " ") + ellipsis
+}
+
+// cutUTF8 returns the longest prefix of s that is at most n octets and ends on a
+// character boundary.
+func cutUTF8(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func (c *Client) checkFrom(from string) error {
+	if len(c.allowedFromDomains) == 0 {
+		return nil
+	}
+
+	address := from
+	if parsed, err := mail.ParseAddress(from); err == nil {
+		address = parsed.Address
+	}
+	domain := strings.ToLower(address[strings.LastIndex(address, "@")+1:])
+	if !c.allowedFromDomains[domain] {
+		return fmt.Errorf("%w: %q", ErrFromDomainNotAllowed, from)
+	}
+	return nil
+}
+
+// streamType returns the configured type of the stream, or "" when it is unknown.
+func (c *Client) streamType(stream string) MessageStreamType {
+	if stream == "" {
+		stream = defaultMessageStream
+	}
+	return c.streamTypes[stream]
+}
+
+// checkStream rejects emails on inbound streams, and broadcast emails that give the
+// recipient no way to unsubscribe.
+func (c *Client) checkStream(email EmailRequest) error {
+	switch c.streamType(email.MessageStream) {
+	case MessageStreamInbound:
+		return fmt.Errorf("%w: cannot send on inbound stream %q", ErrStreamContentMismatch, email.MessageStream)
+	case MessageStreamBroadcast:
+		if !hasUnsubscribe(email) {
+			return fmt.Errorf("%w: broadcast stream %q requires a List-Unsubscribe header or unsubscribe link",
+				ErrStreamContentMismatch, email.MessageStream)
+		}
+	}
+	return nil
+}
+
+func hasUnsubscribe(email EmailRequest) bool {
+	for _, header := range email.Headers {
+		if strings.EqualFold(header.Name, "List-Unsubscribe") {
+			return true
+		}
+	}
+	return hasUnsubscribeLink(email.HtmlBody) || hasUnsubscribeLink(email.TextBody)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func newSendServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"MessageID":"id","ErrorCode":0,"Message":"OK"}`)
+	}))
+}
+
+func TestSubjectLength(t *testing.T) {
+	server := newSendServer(t)
+	defer server.Close()
+	client := NewClient("token", WithBaseURL(server.URL))
+	email := EmailRequest{From: "s@example.com", To: "r@example.com", TextBody: "b"}
+
+	email.Subject = strings.Repeat("a", 78)
+	emailResponse, err := client.SendEmail(email)
+	if err != nil || len(emailResponse.Warnings) != 0 {
+		t.Errorf("78 octet subject: warnings %v, err %v", emailResponse.Warnings, err)
+	}
+
+	email.Subject = strings.Repeat("a", 120)
+	emailResponse, err = client.SendEmail(email)
+	if err != nil || len(emailResponse.Warnings) != 1 {
+		t.Errorf("120 octet subject: warnings %v, err %v, want one warning", emailResponse.Warnings, err)
+	}
+
+	email.Subject = strings.Repeat("a", 999)
+	if _, err := client.SendEmail(email); !errors.Is(err, ErrSubjectTooLong) {
+		t.Errorf("999 octet subject: err = %v, want ErrSubjectTooLong", err)
+	}
+
+	strict := NewClient("token", WithBaseURL(server.URL), WithMaxSubjectLength(100))
+	email.Subject = strings.Repeat("a", 120)
+	if _, err := strict.SendEmail(email); !errors.Is(err, ErrSubjectTooLong) {
+		t.Errorf("120 octet subject with limit 100: err = %v, want ErrSubjectTooLong", err)
+	}
+}
+
+func TestTruncateSubject(t *testing.T) {
+	tests := []struct {
+		subject string
+		max     int
+		want    string
+	}{
+		{"short", 10, "short"},
+		{"hello world", 8, "hello…"},
+		{"日本語の件名", 10, "日本…"},
+		{"héllo", 2, "h"},
+		{"hello", 0, ""},
+		{"hello", -1, ""},
+	}
+	for _, test := range tests {
+		got := TruncateSubject(test.subject, test.max)
+		if got != test.want || len(got) > max(test.max, 0) {
+			t.Errorf("TruncateSubject(%q, %d) = %q, want %q", test.subject, test.max, got, test.want)
+		}
+	}
+}
+
+func TestSendEmailUTF8RoundTrip(t *testing.T) {
+	var contentType string
+	var sent EmailRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		if !utf8.Valid(data) {
+			t.Error("request body is not valid UTF-8")
+		}
+		if err := json.Unmarshal(data, &sent); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		fmt.Fprint(w, `{"MessageID":"id","ErrorCode":0,"Message":"OK"}`)
+	}))
+	defer server.Close()
+
+	email := EmailRequest{
+		From:     "差出人 <sender@example.com>",
+		To:       "r@example.com",
+		Subject:  "夏のキャンペーン 🎉 限定セール",
+		HtmlBody: "<p>こんにちは 👋</p>",
+		TextBody: "こんにちは 👋",
+	}
+	client := NewClient("token", WithBaseURL(server.URL))
+	if _, err := client.SendEmail(email); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q", contentType)
+	}
+	if sent.From != email.From || sent.Subject != email.Subject || sent.HtmlBody != email.HtmlBody || sent.TextBody != email.TextBody {
+		t.Errorf("server got %+v, want %+v", sent, email)
+	}
+}
+
+func TestSendEmailInvalidUTF8(t *testing.T) {
+	server := newSendServer(t)
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	// "日本" encoded as Shift JIS.
+	_, err := client.SendEmail(EmailRequest{From: "s@example.com", To: "r@example.com", Subject: "\x93\xfa\x96\x7b", TextBody: "b"})
+	if !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("err = %v, want ErrInvalidEncoding", err)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// circuitBreaker fast-fails requests after a run of consecutive transient failures.
+// Once the cooldown has passed requests are let through again; a single further
+// failure reopens the circuit and a success closes it. A nil breaker allows everything.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !time.Now().Before(b.openUntil)
+}
+
+// record counts a finished request; only transient failures move towards opening the circuit.
+func (b *circuitBreaker) record(transientFailure bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !transientFailure {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// recordError counts a failed request. Timeouts count as transient failures even when
+// they were not retried, while a cancelled request says nothing about the server and
+// leaves the count untouched.
+func (b *circuitBreaker) recordError(retryable bool, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	var netErr net.Error
+	timeout := errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+	b.record(retryable || timeout)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DeliveryStatus is the terminal outcome of a sent message
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "Pending"
+	DeliveryStatusDelivered DeliveryStatus = "Delivered"
+	DeliveryStatusBounced   DeliveryStatus = "Bounced"
+)
+
+// SendAndWaitForDelivery sends an email and then polls its details every poll interval
+// until Postmark records a delivery or bounce. A message Postmark does not know about yet
+// counts as pending. If ctx ends first the status is DeliveryStatusPending and the
+// context's error is returned.
+func (c *Client) SendAndWaitForDelivery(ctx context.Context, email EmailRequest, poll time.Duration) (DeliveryStatus, error) {
+	if poll <= 0 {
+		return DeliveryStatusPending, fmt.Errorf("poll interval must be positive, got %s", poll)
+	}
+
+	emailResponse, err := c.Send(ctx, email)
+	if err != nil {
+		return DeliveryStatusPending, err
+	}
+
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return DeliveryStatusPending, ctx.Err()
+		case <-ticker.C:
+		}
+
+		details, err := c.getOutboundMessageDetails(ctx, emailResponse.MessageID)
+		if errors.Is(err, ErrMessageNotFound) {
+			continue
+		}
+		if err != nil {
+			return DeliveryStatusPending, err
+		}
+		for _, event := range details.MessageEvents {
+			switch DeliveryStatus(event.Type) {
+			case DeliveryStatusDelivered, DeliveryStatusBounced:
+				return DeliveryStatus(event.Type), nil
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendAndWaitForDeliveryRejectsNonPositivePoll(t *testing.T) {
+	client := NewClient("token", WithBaseURL("http://127.0.0.1:0"))
+	if _, err := client.SendAndWaitForDelivery(context.Background(), EmailRequest{}, 0); err == nil {
+		t.Fatal("want an error for a zero poll interval")
+	}
+}
+
+func TestSendAndWaitForDeliveryWaitsForUnknownMessage(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/email" {
+			fmt.Fprint(w, `{"MessageID":"m1","ErrorCode":0,"Message":"OK"}`)
+			return
+		}
+		if polls.Add(1) < 3 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"ErrorCode":701,"Message":"This message was not found."}`)
+			return
+		}
+		fmt.Fprint(w, `{"MessageID":"m1","MessageEvents":[{"Type":"Delivered"}]}`)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := NewClient("token", WithBaseURL(server.URL))
+	status, err := client.SendAndWaitForDelivery(ctx, EmailRequest{From: "s@example.com", To: "r@example.com", Subject: "s", TextBody: "b"}, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != DeliveryStatusDelivered {
+		t.Errorf("status = %s, want %s", status, DeliveryStatusDelivered)
+	}
+}
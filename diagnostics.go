@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DiagnosticReport describes the client's effective configuration and whether it can
+// reach Postmark. Tokens are reported redacted, showing only their last four characters.
+type DiagnosticReport struct {
+	BaseURL           string
+	ServerToken       string
+	AccountToken      string
+	MaxRetries        int
+	CircuitBreaker    bool
+	MaxConcurrency    int
+	ServerID          int64
+	ServerName        string
+	RoundTripDuration time.Duration
+}
+
+// Diagnostics reports the client's configuration and fetches its server with GetServer
+// to check connectivity. When the request fails the returned report still holds the
+// configuration and the time taken, alongside the error.
+func (c *Client) Diagnostics(ctx context.Context) (DiagnosticReport, error) {
+	report := DiagnosticReport{
+		BaseURL:        c.baseURL,
+		ServerToken:    redactToken(c.apiToken),
+		AccountToken:   redactToken(c.accountToken),
+		MaxRetries:     c.maxRetries,
+		CircuitBreaker: c.breaker != nil,
+	}
+	if c.limiter != nil {
+		report.MaxConcurrency = c.limiter.size
+	}
+
+	start := time.Now()
+	server, err := c.getServer(ctx)
+	report.RoundTripDuration = time.Since(start)
+	if err != nil {
+		return report, fmt.Errorf("failed to reach Postmark: %w", err)
+	}
+	report.ServerID = server.ID
+	report.ServerName = server.Name
+	return report, nil
+}
+
+// redactToken hides all but the last four characters of a token. An empty token stays
+// empty so a missing one is obvious.
+func redactToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	if len(token) <= 4 {
+		return "****"
+	}
+	return "****" + token[len(token)-4:]
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TemplateDiff reports how the templates on two servers differ, matched by alias.
+// Templates without an alias cannot be matched and are not compared.
+type TemplateDiff struct {
+	OnlyInSource      []string
+	OnlyInDestination []string
+	Changed           []TemplateChange
+}
+
+// TemplateChange lists the fields that differ for one alias
+type TemplateChange struct {
+	Alias  string
+	Fields []string
+}
+
+// DiffServers compares the templates of the source and destination servers
+func DiffServers(srcClient, dstClient *Client) (TemplateDiff, error) {
+	srcAliases, err := srcClient.templateAliases()
+	if err != nil {
+		return TemplateDiff{}, fmt.Errorf("failed to list source templates: %w", err)
+	}
+	dstAliases, err := dstClient.templateAliases()
+	if err != nil {
+		return TemplateDiff{}, fmt.Errorf("failed to list destination templates: %w", err)
+	}
+
+	var diff TemplateDiff
+	for alias := range dstAliases {
+		if !srcAliases[alias] {
+			diff.OnlyInDestination = append(diff.OnlyInDestination, alias)
+		}
+	}
+	for alias := range srcAliases {
+		if !dstAliases[alias] {
+			diff.OnlyInSource = append(diff.OnlyInSource, alias)
+			continue
+		}
+
+		src, err := srcClient.GetTemplate(alias)
+		if err != nil {
+			return TemplateDiff{}, fmt.Errorf("failed to get source template %s: %w", alias, err)
+		}
+		dst, err := dstClient.GetTemplate(alias)
+		if err != nil {
+			return TemplateDiff{}, fmt.Errorf("failed to get destination template %s: %w", alias, err)
+		}
+		if fields := changedTemplateFields(src.PostmarkTemplate, dst.PostmarkTemplate); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, TemplateChange{Alias: alias, Fields: fields})
+		}
+	}
+
+	sort.Strings(diff.OnlyInSource)
+	sort.Strings(diff.OnlyInDestination)
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Alias < diff.Changed[j].Alias })
+	return diff, nil
+}
+
+func (c *Client) templateAliases() (map[string]bool, error) {
+	templates, err := c.getAllTemplates()
+	if err != nil {
+		return nil, err
+	}
+	aliases := make(map[string]bool, len(templates))
+	for _, template := range templates {
+		if template.Alias != "" {
+			aliases[template.Alias] = true
+		}
+	}
+	return aliases, nil
+}
+
+func changedTemplateFields(a, b PostmarkTemplate) []string {
+	var fields []string
+	if a.Name != b.Name {
+		fields = append(fields, "Name")
+	}
+	if a.Subject != b.Subject {
+		fields = append(fields, "Subject")
+	}
+	if a.HtmlBody != b.HtmlBody {
+		fields = append(fields, "HtmlBody")
+	}
+	if a.TextBody != b.TextBody {
+		fields = append(fields, "TextBody")
+	}
+	if a.Active != b.Active {
+		fields = append(fields, "Active")
+	}
+	return fields
+}
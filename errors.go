@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrMessageTooLarge is returned when a request body exceeds the configured maximum size
+var ErrMessageTooLarge = errors.New("message too large")
+
+// ErrTemplateActive is returned by safe deletes that would remove an active template
+var ErrTemplateActive = errors.New("refusing to delete active template")
+
+// ErrUnsupportedRegion is returned when WithRegion names a region Postmark has no endpoint for
+var ErrUnsupportedRegion = errors.New("unsupported region")
+
+// ErrInvalidMessageStreamType is returned when creating a message stream of an unknown type
+var ErrInvalidMessageStreamType = errors.New("invalid message stream type")
+
+// ErrCircuitOpen is returned while the circuit breaker is refusing requests
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// ErrInvalidEmail is returned when an email is missing required fields
+var ErrInvalidEmail = errors.New("invalid email")
+
+// ErrServiceUnavailable is returned when Postmark answers 503, typically during maintenance
+var ErrServiceUnavailable = errors.New("postmark service unavailable")
+
+// ErrInvalidModel is returned when a template model does not match its schema
+var ErrInvalidModel = errors.New("invalid template model")
+
+var (
+	// ErrInvalidToken is matched by errors for a missing or wrong API token
+	ErrInvalidToken = errors.New("invalid or missing API token")
+	// ErrInactiveRecipient is matched by errors for recipients Postmark has deactivated
+	ErrInactiveRecipient = errors.New("inactive recipient")
+	// ErrRateLimited is matched by errors for requests Postmark throttled
+	ErrRateLimited = errors.New("rate limited")
+	// ErrTemplateNotFound is matched by errors for templates that do not exist
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrMessageNotFound is matched by errors for messages Postmark has no record of (yet)
+	ErrMessageNotFound = errors.New("message not found")
+)
+
+// Postmark API error codes with a matching sentinel error
+const (
+	errorCodeInvalidToken      = 10
+	errorCodeInactiveRecipient = 406
+	errorCodeMessageNotFound   = 701
+	errorCodeTemplateNotFound  = 1101
+	// errorCodeMissingResult is not Postmark's: it marks batch messages Postmark returned no result for
+	errorCodeMissingResult = -1
+)
+
+func errorForCode(code int) error {
+	switch code {
+	case errorCodeInvalidToken:
+		return ErrInvalidToken
+	case errorCodeInactiveRecipient:
+		return ErrInactiveRecipient
+	case errorCodeMessageNotFound:
+		return ErrMessageNotFound
+	case errorCodeTemplateNotFound:
+		return ErrTemplateNotFound
+	case errorCodeMissingResult:
+		return ErrMissingResult
+	}
+	return nil
+}
+
+// APIError is an error Postmark reported in the ErrorCode and Message of a response
+type APIError struct {
+	ErrorCode int
+	Message   string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Unwrap lets errors.Is match the sentinel error for well-known error codes
+func (e *APIError) Unwrap() error {
+	return errorForCode(e.ErrorCode)
+}
+
+// StatusError is returned when Postmark answers with a non-200 status. ErrorCode and
+// Message are filled in when the body is a Postmark error response. ReadErr is set when
+// the body could not be read in full, in which case Body holds only what was received.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	ErrorCode  int
+	Message    string
+	ReadErr    error
+}
+
+func (e *StatusError) Error() string {
+	if e.ReadErr != nil {
+		return fmt.Sprintf("unexpected status code: %d, response: %s (failed to read response body: %v)", e.StatusCode, e.Body, e.ReadErr)
+	}
+	return fmt.Sprintf("unexpected status code: %d, response: %s", e.StatusCode, e.Body)
+}
+
+// Unwrap lets errors.Is match the sentinel error for the status code or Postmark error
+// code, as well as the body read error
+func (e *StatusError) Unwrap() []error {
+	var errs []error
+	if sentinel := e.sentinel(); sentinel != nil {
+		errs = append(errs, sentinel)
+	}
+	if e.ReadErr != nil {
+		errs = append(errs, e.ReadErr)
+	}
+	return errs
+}
+
+func (e *StatusError) sentinel() error {
+	switch e.StatusCode {
+	case http.StatusServiceUnavailable:
+		return ErrServiceUnavailable
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusUnauthorized:
+		return ErrInvalidToken
+	}
+	return errorForCode(e.ErrorCode)
+}
+
+// ErrFromDomainNotAllowed is returned when an email's From domain is not in the allowed set
+var ErrFromDomainNotAllowed = errors.New("from domain not allowed")
+
+// ErrBatchIncomplete is returned by SendEmailBatchAtomic when only part of a batch was accepted
+var ErrBatchIncomplete = errors.New("batch incomplete")
+
+// ErrStreamContentMismatch is returned when an email's content does not suit the type of its message stream
+var ErrStreamContentMismatch = errors.New("content does not match message stream type")
+
+// ErrHeaderInjection is returned when a From, ReplyTo or Subject value contains CR, LF or other control characters
+var ErrHeaderInjection = errors.New("control character in header field")
+
+// ErrResponseTooLarge is returned when a response body exceeds the WithMaxResponseSize limit
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ErrAliasExists is returned by CreateTemplate when another template already has the alias
+var ErrAliasExists = errors.New("template alias already exists")
+
+// ErrAccountTokenRequired is returned by account-level methods when WithAccountToken was not used
+var ErrAccountTokenRequired = errors.New("account token required")
+
+// ErrSubjectTooLong is returned when an email's Subject exceeds the WithMaxSubjectLength limit
+var ErrSubjectTooLong = errors.New("subject too long")
+
+// ErrInvalidEncoding is returned when an email's addresses, Subject or body are not valid UTF-8
+var ErrInvalidEncoding = errors.New("invalid UTF-8")
+
+// ErrSearchDepth is returned when a search cannot be paged any further because Postmark
+// limits count + offset to 10,000
+var ErrSearchDepth = errors.New("search depth limit reached")
+
+// ErrMissingResult is matched by batch failures for messages Postmark returned no result for
+var ErrMissingResult = errors.New("no result for batch message")
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// NewTemplateFromFiles builds a template whose bodies are read from disk. Either path
+// may be empty to leave that body blank.
+func NewTemplateFromFiles(name, alias, subject, htmlPath, textPath string) (PostmarkTemplate, error) {
+	template := PostmarkTemplate{
+		Name:    name,
+		Alias:   alias,
+		Subject: subject,
+	}
+
+	if htmlPath != "" {
+		htmlBody, err := os.ReadFile(htmlPath)
+		if err != nil {
+			return PostmarkTemplate{}, fmt.Errorf("failed to read html body: %w", err)
+		}
+		template.HtmlBody = string(htmlBody)
+	}
+
+	if textPath != "" {
+		textBody, err := os.ReadFile(textPath)
+		if err != nil {
+			return PostmarkTemplate{}, fmt.Errorf("failed to read text body: %w", err)
+		}
+		template.TextBody = string(textBody)
+	}
+
+	return template, nil
+}
@@ -0,0 +1,19 @@
+package main
+
+import "sync/atomic"
+
+// fromPool hands out sender addresses in round-robin order
+type fromPool struct {
+	addrs []string
+	next  atomic.Uint64
+}
+
+func (p *fromPool) pick() string {
+	n := p.next.Add(1) - 1
+	return p.addrs[n%uint64(len(p.addrs))]
+}
+
+// peek returns the address the nth following pick will hand out, without advancing the pool.
+func (p *fromPool) peek(n int) string {
+	return p.addrs[(p.next.Load()+uint64(n))%uint64(len(p.addrs))]
+}
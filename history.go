@@ -0,0 +1,32 @@
+package main
+
+import "sync"
+
+// HistoryStore records the content of a template before UpdateTemplate overwrites it
+type HistoryStore interface {
+	Save(ID uint64, previous PostmarkTemplateResponse) error
+}
+
+// MemoryHistoryStore keeps template history in memory
+type MemoryHistoryStore struct {
+	mu       sync.Mutex
+	versions map[uint64][]PostmarkTemplateResponse
+}
+
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{versions: make(map[uint64][]PostmarkTemplateResponse)}
+}
+
+func (s *MemoryHistoryStore) Save(ID uint64, previous PostmarkTemplateResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.versions[ID] = append(s.versions[ID], previous)
+	return nil
+}
+
+// Versions returns the recorded versions of a template, oldest first
+func (s *MemoryHistoryStore) Versions(ID uint64) []PostmarkTemplateResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]PostmarkTemplateResponse(nil), s.versions[ID]...)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// InboundMessage represents a message delivered to an inbound webhook
+type InboundMessage struct {
+	MessageID         string              `json:"MessageID"`
+	MessageStream     string              `json:"MessageStream"`
+	From              string              `json:"From"`
+	FromName          string              `json:"FromName"`
+	FromFull          InboundAddress      `json:"FromFull"`
+	To                string              `json:"To"`
+	ToFull            []InboundAddress    `json:"ToFull"`
+	Cc                string              `json:"Cc"`
+	CcFull            []InboundAddress    `json:"CcFull"`
+	Bcc               string              `json:"Bcc"`
+	BccFull           []InboundAddress    `json:"BccFull"`
+	OriginalRecipient string              `json:"OriginalRecipient"`
+	ReplyTo           string              `json:"ReplyTo"`
+	MailboxHash       string              `json:"MailboxHash"`
+	Subject           string              `json:"Subject"`
+	Date              string              `json:"Date"`
+	TextBody          string              `json:"TextBody"`
+	HtmlBody          string              `json:"HtmlBody"`
+	StrippedTextReply string              `json:"StrippedTextReply"`
+	Tag               string              `json:"Tag"`
+	Headers           []InboundHeader     `json:"Headers"`
+	Attachments       []InboundAttachment `json:"Attachments"`
+}
+
+// InboundAddress represents a parsed address on an inbound message
+type InboundAddress struct {
+	Email       string `json:"Email"`
+	Name        string `json:"Name"`
+	MailboxHash string `json:"MailboxHash"`
+}
+
+// InboundHeader represents a raw header on an inbound message
+type InboundHeader struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// InboundAttachment represents a file attached to an inbound message
+type InboundAttachment struct {
+	Name          string `json:"Name"`
+	Content       string `json:"Content"`
+	ContentType   string `json:"ContentType"`
+	ContentLength int    `json:"ContentLength"`
+}
+
+// ParseInboundMessage decodes an inbound webhook payload
+func ParseInboundMessage(r io.Reader) (InboundMessage, error) {
+	var message InboundMessage
+	if err := json.NewDecoder(r).Decode(&message); err != nil {
+		return InboundMessage{}, fmt.Errorf("failed to decode inbound message: %w", err)
+	}
+	return message, nil
+}
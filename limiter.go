@@ -0,0 +1,128 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"io"
+	"sync"
+)
+
+// Priority orders requests waiting for a WithMaxConcurrency slot. Higher priorities are
+// sent first; requests of equal priority are sent in the order they arrived.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+// limiter caps the requests in flight and hands freed slots to the highest priority waiter.
+type limiter struct {
+	mu      sync.Mutex
+	size    int
+	inUse   int
+	seq     uint64
+	waiters waitQueue
+}
+
+func newLimiter(size int) *limiter {
+	return &limiter{size: size}
+}
+
+// acquire blocks until a slot is free or ctx is done.
+func (l *limiter) acquire(ctx context.Context, priority Priority) error {
+	l.mu.Lock()
+	if l.inUse < l.size && len(l.waiters) == 0 {
+		l.inUse++
+		l.mu.Unlock()
+		return nil
+	}
+	l.seq++
+	w := &waiter{priority: priority, seq: l.seq, ready: make(chan struct{})}
+	heap.Push(&l.waiters, w)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		select {
+		case <-w.ready:
+			// The slot was handed over just as ctx ended; pass it on.
+			l.releaseLocked()
+		default:
+			heap.Remove(&l.waiters, w.index)
+		}
+		return ctx.Err()
+	}
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.releaseLocked()
+}
+
+func (l *limiter) releaseLocked() {
+	if len(l.waiters) == 0 {
+		l.inUse--
+		return
+	}
+	w := heap.Pop(&l.waiters).(*waiter)
+	close(w.ready)
+}
+
+// releasingBody gives back a limiter slot the first time the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+type waiter struct {
+	priority Priority
+	seq      uint64
+	index    int
+	ready    chan struct{}
+}
+
+// waitQueue is a container/heap of waiters, highest priority and then oldest first.
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return w
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var mustacheTagPattern = regexp.MustCompile(`{{{?\s*([#^/>]?)\s*([^{}]*?)\s*}?}}`)
+
+// LintIssue describes a problem found in a template field
+type LintIssue struct {
+	Field   string
+	Message string
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Field, i.Message)
+}
+
+// LintTemplate checks a template locally for unbalanced block sections and
+// partial references, neither of which Postmark can render
+func LintTemplate(t PostmarkTemplate) []LintIssue {
+	var issues []LintIssue
+	for _, field := range templateFields(t) {
+		issues = append(issues, lintMustache(field.name, field.content)...)
+	}
+	return issues
+}
+
+// LintBroadcastTemplate runs LintTemplate and additionally requires an unsubscribe
+// link in every body, as mail sent on a broadcast stream must carry one
+func LintBroadcastTemplate(t PostmarkTemplate) []LintIssue {
+	issues := LintTemplate(t)
+	for _, field := range templateFields(t)[1:] {
+		if field.content != "" && !hasUnsubscribeLink(field.content) {
+			issues = append(issues, LintIssue{Field: field.name, Message: "missing unsubscribe link"})
+		}
+	}
+	return issues
+}
+
+type templateField struct {
+	name    string
+	content string
+}
+
+func templateFields(t PostmarkTemplate) []templateField {
+	return []templateField{
+		{name: "Subject", content: t.Subject},
+		{name: "HtmlBody", content: t.HtmlBody},
+		{name: "TextBody", content: t.TextBody},
+	}
+}
+
+func lintMustache(field, content string) []LintIssue {
+	var issues []LintIssue
+	var open []string
+	for _, match := range mustacheTagPattern.FindAllStringSubmatch(content, -1) {
+		kind, name := match[1], match[2]
+		block := strings.Fields(name)
+		switch kind {
+		case "#", "^":
+			if len(block) == 0 {
+				issues = append(issues, LintIssue{Field: field, Message: "empty block section"})
+				continue
+			}
+			open = append(open, block[0])
+		case "/":
+			i := len(open) - 1
+			for i >= 0 && open[i] != name {
+				i--
+			}
+			if i < 0 {
+				issues = append(issues, LintIssue{Field: field, Message: fmt.Sprintf("unexpected closing {{/%s}}", name)})
+				continue
+			}
+			for _, unclosed := range open[i+1:] {
+				issues = append(issues, LintIssue{Field: field, Message: fmt.Sprintf("unclosed {{#%s}}", unclosed)})
+			}
+			open = open[:i]
+		case ">":
+			issues = append(issues, LintIssue{Field: field, Message: fmt.Sprintf("undefined partial %q", name)})
+		}
+	}
+	for _, name := range open {
+		issues = append(issues, LintIssue{Field: field, Message: fmt.Sprintf("unclosed {{#%s}}", name)})
+	}
+	return issues
+}
+
+// hasUnsubscribeLink accepts Postmark's {{{pm:unsubscribe}}} placeholder or any custom unsubscribe link.
+func hasUnsubscribeLink(content string) bool {
+	return strings.Contains(strings.ToLower(content), "unsubscribe")
+}
+
+// LintTemplateModel checks that the variables a template uses outside of block
+// sections are present in model, and warns when the Subject would render empty.
+func LintTemplateModel(t PostmarkTemplate, model map[string]interface{}) []LintIssue {
+	var issues []LintIssue
+	for _, field := range templateFields(t) {
+		for _, name := range topLevelVariables(field.content) {
+			if _, ok := lookupModel(model, name); !ok {
+				issues = append(issues, LintIssue{Field: field.name, Message: fmt.Sprintf("variable %q is not in the model", name)})
+			}
+		}
+	}
+	if t.Subject != "" && strings.TrimSpace(renderVariables(t.Subject, model)) == "" {
+		issues = append(issues, LintIssue{Field: "Subject", Message: "subject renders empty with this model"})
+	}
+	return issues
+}
+
+// topLevelVariables lists the variables referenced outside any block section.
+func topLevelVariables(content string) []string {
+	var names []string
+	depth := 0
+	for _, match := range mustacheTagPattern.FindAllStringSubmatch(content, -1) {
+		kind, name := match[1], match[2]
+		switch kind {
+		case "#", "^":
+			depth++
+		case "/":
+			depth = max(depth-1, 0)
+		case "":
+			if depth == 0 && isModelVariable(name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// isModelVariable excludes Postmark placeholders such as {{{pm:unsubscribe}}} and {{{@content}}}.
+func isModelVariable(name string) bool {
+	return name != "" && name != "this" && name != "else" &&
+		!strings.HasPrefix(name, "pm:") && !strings.HasPrefix(name, "@")
+}
+
+// renderVariables substitutes top-level variables and drops every other tag, which is
+// enough to tell whether a single line such as a subject renders to anything.
+func renderVariables(content string, model map[string]interface{}) string {
+	return mustacheTagPattern.ReplaceAllStringFunc(content, func(tag string) string {
+		match := mustacheTagPattern.FindStringSubmatch(tag)
+		if match[1] != "" {
+			return ""
+		}
+		value, ok := lookupModel(model, match[2])
+		if !ok || value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	})
+}
+
+func lookupModel(model map[string]interface{}, name string) (interface{}, bool) {
+	var value interface{} = model
+	for _, key := range strings.Split(name, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
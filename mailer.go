@@ -0,0 +1,11 @@
+package main
+
+import "context"
+
+// Mailer sends a single email. Client implements it; depend on Mailer instead of
+// *Client where the code only needs to send, so it can be replaced in tests.
+type Mailer interface {
+	Send(ctx context.Context, email EmailRequest) (EmailResponse, error)
+}
+
+var _ Mailer = (*Client)(nil)
@@ -2,11 +2,17 @@ package main
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 type PostmarkTemplate struct {
@@ -14,136 +20,612 @@ type PostmarkTemplate struct {
 	Subject  string `json:"Subject"`
 	HtmlBody string `json:"HtmlBody"`
 	TextBody string `json:"TextBody"`
+	Alias    string `json:"Alias,omitempty"`
 	Active   bool   `json:"active,omitempty"`
 }
 
+type PostmarkTemplateResponse struct {
+	TemplateID         int64 `json:"TemplateId"`
+	AssociatedServerID int64 `json:"AssociatedServerId"`
+	PostmarkTemplate
+
+	// CreatedAt and UpdatedAt are decoded when Postmark includes them in the
+	// response and are left zero otherwise.
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
 type PostmarkResponse struct {
 	ErrorCode  int    `json:"ErrorCode"`
 	Message    string `json:"Message"`
 	TemplateID int64  `json:"TemplateID"`
+	Name       string `json:"Name,omitempty"`
+	Alias      string `json:"Alias,omitempty"`
 }
 
 type PostmarkTemplateListResponse struct {
-	Templates []PostmarkTemplateDetails `json:"Templates"`
+	TotalCount int                       `json:"TotalCount"`
+	Templates  []PostmarkTemplateDetails `json:"Templates"`
 }
 
 type PostmarkTemplateDetails struct {
 	TemplateID int64  `json:"TemplateId"`
 	Name       string `json:"Name"`
+	Alias      string `json:"Alias"`
 	Subject    string `json:"Subject"`
 	Active     bool   `json:"Active"`
 }
 
+// EmailRequest has no Return-Path field: Postmark sets the bounce address from the
+// sender domain's ReturnPathDomain, so per-tenant bounce routing needs a sender
+// domain per tenant rather than a per-message override.
+//
+// TrackOpens is a pointer so that an explicit false is sent and overrides the server's
+// default; leave it nil to use the default.
 type EmailRequest struct {
-	From       string `json:"From"`
-	To         string `json:"To"`
-	Subject    string `json:"Subject"`
-	HtmlBody   string `json:"HtmlBody"`
-	TextBody   string `json:"TextBody"`
-	TemplateID int    `json:"TemplateID"`
+	From          string            `json:"From"`
+	To            string            `json:"To"`
+	Cc            string            `json:"Cc,omitempty"`
+	Bcc           string            `json:"Bcc,omitempty"`
+	ReplyTo       string            `json:"ReplyTo,omitempty"`
+	Subject       string            `json:"Subject"`
+	HtmlBody      string            `json:"HtmlBody"`
+	TextBody      string            `json:"TextBody"`
+	TemplateID    int64             `json:"TemplateID"`
+	Tag           string            `json:"Tag,omitempty"`
+	Headers       []Header          `json:"Headers,omitempty"`
+	Metadata      map[string]string `json:"Metadata,omitempty"`
+	Attachments   []Attachment      `json:"Attachments,omitempty"`
+	MessageStream string            `json:"MessageStream,omitempty"`
+	TrackOpens    *bool             `json:"TrackOpens,omitempty"`
+	TrackLinks    LinkTracking      `json:"TrackLinks,omitempty"`
+
+	// Priority decides which send goes first when WithMaxConcurrency makes requests wait.
+	Priority Priority `json:"-"`
+}
+
+type Header struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
 }
 
 type EmailResponse struct {
-	To      string `json:"To"`
-	Message string `json:"Message"`
+	MessageID   string    `json:"MessageID"`
+	To          string    `json:"To"`
+	SubmittedAt time.Time `json:"SubmittedAt"`
+	ErrorCode   int       `json:"ErrorCode"`
+	Message     string    `json:"Message"`
+
+	// Metadata echoes the request's metadata; Postmark does not return it.
+	Metadata map[string]string `json:"-"`
+	// Suppressed is set when the message was not sent because every recipient is suppressed.
+	Suppressed bool `json:"-"`
+	// Preview holds the rendered content of a templated email sent with Preview set.
+	Preview *RenderedTemplate `json:"-"`
+	// Warnings lists problems found before sending that did not stop the send.
+	Warnings []string `json:"-"`
 }
 
+const (
+	defaultBaseURL     = "https://api.postmarkapp.com"
+	defaultMaxBodySize = 10 * 1024 * 1024
+	// jsonContentType declares UTF-8 explicitly, the only encoding the JSON API accepts
+	jsonContentType = "application/json; charset=utf-8"
+	// maxSubjectLength is RFC 5322's hard limit on a header line, in octets
+	maxSubjectLength = 998
+	// defaultSubjectWarningLength is about where mail clients start cutting subjects off
+	defaultSubjectWarningLength = 78
+)
+
+// Client is safe for concurrent use by multiple goroutines. Its configuration is
+// fixed by the options passed to NewClient and never mutated afterwards; any
+// stateful option must guard its own state.
 type Client struct {
-	baseURL    string
-	apiToken   string
-	httpClient *http.Client
+	baseURL     string
+	apiToken    string
+	httpClient  *http.Client
+	doer        Doer
+	maxBodySize int
+	defaultTag  string
+	maxRetries  int
+	retryBase   time.Duration
+	retryJitter bool
+
+	autoTextBody  bool
+	safeDelete    bool
+	history       HistoryStore
+	responseHook  func(endpoint string, result interface{})
+	breaker       *circuitBreaker
+	codec         Codec
+	limiter       *limiter
+	fromPool      *fromPool
+	aliasIDs      sync.Map
+	templateCache *templateCache
+
+	allowedFromDomains map[string]bool
+	autoSuppress       *suppressionCache
+	streamTypes        map[string]MessageStreamType
+	maxResponseSize    int64
+	forceNoTracking    bool
+	accountToken       string
+	maxSubjectLength   int
+	subjectWarnLength  int
+	retryIf            func(resp *http.Response, err error) bool
+	senders            *senderCache
+
+	configErr error
+}
+
+func NewClient(apiToken string, opts ...Option) *Client {
+	httpClient := &http.Client{}
+	c := &Client{
+		baseURL:           defaultBaseURL,
+		apiToken:          apiToken,
+		httpClient:        httpClient,
+		doer:              httpClient,
+		maxBodySize:       defaultMaxBodySize,
+		codec:             jsonCodec{},
+		maxSubjectLength:  maxSubjectLength,
+		subjectWarnLength: defaultSubjectWarningLength,
+		senders:           &senderCache{ttl: defaultSenderCacheTTL},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-func NewClient(apiToken string) *Client {
-	return &Client{
-		baseURL:    "https://api.postmarkapp.com",
-		apiToken:   apiToken,
-		httpClient: &http.Client{},
+func (c *Client) bodySizeLimit(url string) int {
+	if strings.HasPrefix(url, "/email/batch") && c.maxBodySize == defaultMaxBodySize {
+		return defaultMaxBatchBodySize
 	}
+	return c.maxBodySize
 }
 
-func (c *Client) doRequest(method, url string, body interface{}, result interface{}) error {
+func (c *Client) doRequest(method, url string, body interface{}, result interface{}, opts ...requestOption) error {
+	return c.doRequestContext(context.Background(), method, url, body, result, opts...)
+}
+
+// requestConfig holds per-call settings for doRequestContext.
+type requestConfig struct {
+	contentType  string
+	priority     Priority
+	accountToken bool
+}
+
+type requestOption func(*requestConfig)
+
+// withPriority sets the order in which the request gets a WithMaxConcurrency slot.
+func withPriority(priority Priority) requestOption {
+	return func(cfg *requestConfig) {
+		cfg.priority = priority
+	}
+}
+
+// withAccountToken authenticates the request with the WithAccountToken token instead of
+// the server token, for account-level endpoints.
+func withAccountToken() requestOption {
+	return func(cfg *requestConfig) {
+		cfg.accountToken = true
+	}
+}
+
+func (c *Client) doRequestContext(ctx context.Context, method, url string, body interface{}, result interface{}, opts ...requestOption) error {
+	var cfg requestConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if c.configErr != nil {
+		return c.configErr
+	}
+	if cfg.accountToken && c.accountToken == "" {
+		return ErrAccountTokenRequired
+	}
+
+	if !c.breaker.allow() {
+		return ErrCircuitOpen
+	}
+
 	fullURL := c.baseURL + url
+	reqData, contentType, err := c.encodeBody(body)
+	if err != nil {
+		return err
+	}
+	cfg.contentType = contentType
+	if limit := c.bodySizeLimit(url); limit > 0 && len(reqData) > limit {
+		return fmt.Errorf("%w: %d bytes exceeds limit of %d bytes", ErrMessageTooLarge, len(reqData), limit)
+	}
+
+	for attempt := 0; ; attempt++ {
+		retryable, err := c.send(ctx, method, fullURL, reqData, cfg, result)
+		if err == nil {
+			c.breaker.record(false)
+			if c.responseHook != nil && result != nil {
+				c.responseHook(url, result)
+			}
+			return nil
+		}
+		if !retryable || attempt >= c.maxRetries {
+			c.breaker.recordError(retryable, err)
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			c.breaker.recordError(true, err)
+			return err
+		case <-time.After(c.retryDelay(attempt)):
+		}
+	}
+}
+
+// RawBody is a request body sent as is, with its own Content-Type, instead of being
+// marshaled by the client's codec. An empty ContentType means JSON.
+type RawBody struct {
+	ContentType string
+	Data        []byte
+}
+
+// encodeBody marshals a request body with the client's codec and returns it with its
+// Content-Type. A RawBody is passed through unchanged.
+func (c *Client) encodeBody(body interface{}) ([]byte, string, error) {
+	if raw, ok := body.(RawBody); ok {
+		if raw.ContentType == "" {
+			return raw.Data, jsonContentType, nil
+		}
+		return raw.Data, raw.ContentType, nil
+	}
+	if body == nil {
+		return nil, jsonContentType, nil
+	}
+	data, err := c.codec.Marshal(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal body: %w", err)
+	}
+	return data, jsonContentType, nil
+}
+
+// newRequest builds an authenticated API request.
+func (c *Client) newRequest(ctx context.Context, method, fullURL string, reqData []byte, cfg requestConfig) (*http.Request, error) {
 	var reqBody io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
+	if reqData != nil {
+		reqBody = bytes.NewReader(reqData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", cfg.contentType)
+	if cfg.accountToken {
+		req.Header.Set("X-Postmark-Account-Token", c.accountToken)
+	} else {
+		req.Header.Set("X-Postmark-Server-Token", c.apiToken)
+	}
+	return req, nil
+}
+
+// DoRaw sends a request to path, relative to the API base URL, and returns the response
+// as is. It is an escape hatch for endpoints the client does not wrap: the body is
+// marshaled with the client's codec, or sent as is with its own Content-Type when it is
+// a RawBody, but there are no retries, no circuit breaker and no status check. The
+// caller must close the response body; under WithMaxConcurrency the request holds its
+// slot until then.
+func (c *Client) DoRaw(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+
+	reqData, contentType, err := c.encodeBody(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, method, c.baseURL+path, reqData, requestConfig{contentType: contentType})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.limiter == nil {
+		resp, err := c.doer.Do(req)
 		if err != nil {
-			return fmt.Errorf("failed to marshal body: %w", err)
+			return nil, fmt.Errorf("failed to send request: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
+		return resp, nil
 	}
 
-	req, err := http.NewRequest(method, fullURL, reqBody)
+	if err := c.limiter.acquire(ctx, PriorityNormal); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	resp, err := c.doer.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		c.limiter.release()
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: c.limiter.release}
+	return resp, nil
+}
 
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Postmark-Server-Token", c.apiToken)
+// send performs a single attempt and reports whether a failure is worth retrying.
+func (c *Client) send(ctx context.Context, method, fullURL string, reqData []byte, cfg requestConfig, result interface{}) (bool, error) {
+	req, err := c.newRequest(ctx, method, fullURL, reqData, cfg)
+	if err != nil {
+		return false, err
+	}
 
-	resp, err := c.httpClient.Do(req)
+	if c.limiter != nil {
+		if err := c.limiter.acquire(ctx, cfg.priority); err != nil {
+			return false, fmt.Errorf("failed to send request: %w", err)
+		}
+		defer c.limiter.release()
+	}
+
+	resp, err := c.doer.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return ctx.Err() == nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
+		bodyBytes, readErr := c.readBody(resp.Body)
 		bodyString := string(bodyBytes)
-		return fmt.Errorf("unexpected status code: %d, response: %s", resp.StatusCode, bodyString)
+		statusErr := &StatusError{StatusCode: resp.StatusCode, Body: bodyString, ReadErr: readErr}
+		var postmarkResponse PostmarkResponse
+		if c.codec.Unmarshal(bodyBytes, &postmarkResponse) == nil {
+			statusErr.ErrorCode = postmarkResponse.ErrorCode
+			statusErr.Message = postmarkResponse.Message
+		}
+		return c.shouldRetry(resp, statusErr), statusErr
 	}
 
 	if result != nil {
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
+		bodyBytes, err := c.readBody(resp.Body)
+		if err != nil {
+			return false, fmt.Errorf("failed to read response: %w", err)
+		}
+		if err := c.codec.Unmarshal(bodyBytes, result); err != nil {
+			return false, fmt.Errorf("failed to decode response: %w", err)
 		}
 	}
 
-	return nil
+	return false, nil
+}
+
+// readBody reads a response body, failing with ErrResponseTooLarge once it passes the
+// WithMaxResponseSize limit.
+func (c *Client) readBody(body io.Reader) ([]byte, error) {
+	if c.maxResponseSize <= 0 {
+		return io.ReadAll(body)
+	}
+	data, err := io.ReadAll(io.LimitReader(body, c.maxResponseSize+1))
+	if err != nil {
+		return data, err
+	}
+	if int64(len(data)) > c.maxResponseSize {
+		return data[:c.maxResponseSize], fmt.Errorf("%w: exceeds limit of %d bytes", ErrResponseTooLarge, c.maxResponseSize)
+	}
+	return data, nil
 }
 
+// CreateTemplate creates a template and returns its ID. A template whose alias is
+// already taken fails with ErrAliasExists, checked before anything is created.
 func (c *Client) CreateTemplate(template PostmarkTemplate) (int64, error) {
+	postmarkResponse, err := c.CreateTemplateWithResponse(template)
+	if err != nil {
+		return 0, err
+	}
+	return postmarkResponse.TemplateID, nil
+}
+
+// CreateTemplateWithResponse is CreateTemplate that returns Postmark's whole response,
+// including the Name and Alias the template was created with.
+func (c *Client) CreateTemplateWithResponse(template PostmarkTemplate) (PostmarkResponse, error) {
+	if template.Alias != "" {
+		existing, err := c.fetchTemplate(template.Alias)
+		if err == nil {
+			return PostmarkResponse{}, fmt.Errorf("failed to create template: %w: %q is used by template %d", ErrAliasExists, template.Alias, existing.TemplateID)
+		}
+		if !errors.Is(err, ErrTemplateNotFound) {
+			return PostmarkResponse{}, fmt.Errorf("failed to check template alias: %w", err)
+		}
+	}
+
 	var postmarkResponse PostmarkResponse
 	if err := c.doRequest("POST", "/templates", template, &postmarkResponse); err != nil {
-		return 0, err
+		return PostmarkResponse{}, err
 	}
 
 	if postmarkResponse.ErrorCode != 0 {
-		return 0, fmt.Errorf("failed to create template: %s", postmarkResponse.Message)
+		return postmarkResponse, fmt.Errorf("failed to create template: %w", &APIError{ErrorCode: postmarkResponse.ErrorCode, Message: postmarkResponse.Message})
 	}
 
-	return postmarkResponse.TemplateID, nil
+	return postmarkResponse, nil
+}
+
+// CreateTemplates creates each template in turn, continuing past failures. The returned
+// IDs and errors line up with the input; a failed template has ID 0 and a non-nil error.
+func (c *Client) CreateTemplates(templates []PostmarkTemplate) ([]int64, []error) {
+	IDs := make([]int64, len(templates))
+	errs := make([]error, len(templates))
+	for i, template := range templates {
+		IDs[i], errs[i] = c.CreateTemplate(template)
+	}
+	return IDs, errs
 }
 
 func (c *Client) UpdateTemplate(ID uint64, template PostmarkTemplate) error {
+	_, err := c.UpdateTemplateWithResponse(ID, template)
+	return err
+}
+
+// UpdateTemplateWithResponse is UpdateTemplate that also returns Postmark's response,
+// including its Message, on success and on API errors alike.
+func (c *Client) UpdateTemplateWithResponse(ID uint64, template PostmarkTemplate) (PostmarkResponse, error) {
+	if c.history != nil {
+		previous, err := c.fetchTemplate(strconv.FormatUint(ID, 10))
+		if err != nil {
+			return PostmarkResponse{}, err
+		}
+		if err := c.history.Save(ID, *previous); err != nil {
+			return PostmarkResponse{}, fmt.Errorf("failed to record template history: %w", err)
+		}
+	}
+
+	defer c.forgetTemplate(strconv.FormatUint(ID, 10))
 	url := fmt.Sprintf("/templates/%d", ID)
 	var postmarkResponse PostmarkResponse
 	if err := c.doRequest("PUT", url, template, &postmarkResponse); err != nil {
+		return PostmarkResponse{}, err
+	}
+
+	if postmarkResponse.ErrorCode != 0 {
+		return postmarkResponse, fmt.Errorf("failed to update template: %w", &APIError{ErrorCode: postmarkResponse.ErrorCode, Message: postmarkResponse.Message})
+	}
+
+	return postmarkResponse, nil
+}
+
+// PatchTemplate updates only the given fields, e.g. {"Active": false}, leaving the rest of the template untouched.
+func (c *Client) PatchTemplate(ID uint64, fields map[string]interface{}) error {
+	defer c.forgetTemplate(strconv.FormatUint(ID, 10))
+	url := fmt.Sprintf("/templates/%d", ID)
+	var postmarkResponse PostmarkResponse
+	if err := c.doRequest("PUT", url, fields, &postmarkResponse); err != nil {
 		return err
 	}
 
 	if postmarkResponse.ErrorCode != 0 {
-		return fmt.Errorf("failed to update template: %s", postmarkResponse.Message)
+		return fmt.Errorf("failed to patch template: %w", &APIError{ErrorCode: postmarkResponse.ErrorCode, Message: postmarkResponse.Message})
 	}
 
 	return nil
 }
 
+// ArchiveTemplate deactivates a template instead of deleting it. Postmark has no
+// archive endpoint, but an inactive template keeps its content and cannot be sent.
+func (c *Client) ArchiveTemplate(ID uint64) error {
+	return c.PatchTemplate(ID, map[string]interface{}{"Active": false})
+}
+
+// UnarchiveTemplate reactivates a template previously archived with ArchiveTemplate.
+func (c *Client) UnarchiveTemplate(ID uint64) error {
+	return c.PatchTemplate(ID, map[string]interface{}{"Active": true})
+}
+
+func (c *Client) GetTemplate(idOrAlias string) (*PostmarkTemplateResponse, error) {
+	if c.templateCache == nil {
+		return c.fetchTemplate(idOrAlias)
+	}
+	if template, ok := c.templateCache.get(idOrAlias); ok {
+		return &template, nil
+	}
+
+	template, err := c.fetchTemplate(idOrAlias)
+	if err != nil {
+		return nil, err
+	}
+	c.templateCache.set(idOrAlias, *template)
+	return template, nil
+}
+
+// fetchTemplate always reads the template from Postmark, bypassing the template cache.
+func (c *Client) fetchTemplate(idOrAlias string) (*PostmarkTemplateResponse, error) {
+	url := "/templates/" + neturl.PathEscape(idOrAlias)
+	var template PostmarkTemplateResponse
+	if err := c.doRequest("GET", url, nil, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ResolveTemplateAlias returns the numeric ID of the template with the given alias.
+// Resolved aliases are remembered for the lifetime of the client or, with
+// WithTemplateCache, for the cache's ttl.
+func (c *Client) ResolveTemplateAlias(alias string) (int64, error) {
+	if c.templateCache != nil {
+		template, err := c.GetTemplate(alias)
+		if err != nil {
+			return 0, err
+		}
+		return template.TemplateID, nil
+	}
+
+	if ID, ok := c.aliasIDs.Load(alias); ok {
+		return ID.(int64), nil
+	}
+
+	template, err := c.fetchTemplate(alias)
+	if err != nil {
+		return 0, err
+	}
+	c.aliasIDs.Store(alias, template.TemplateID)
+	return template.TemplateID, nil
+}
+
+// CloneTemplate copies an existing template under a new name and alias and returns the new template's ID.
+func (c *Client) CloneTemplate(sourceIdOrAlias, newName, newAlias string) (int64, error) {
+	source, err := c.GetTemplate(sourceIdOrAlias)
+	if err != nil {
+		return 0, err
+	}
+
+	clone := source.PostmarkTemplate
+	clone.Name = newName
+	clone.Alias = newAlias
+	return c.CreateTemplate(clone)
+}
+
 func (c *Client) DeleteTemplate(ID uint64) error {
-	url := fmt.Sprintf("/templates/%d", ID)
+	_, err := c.deleteTemplate(strconv.FormatUint(ID, 10), false)
+	return err
+}
+
+// DeleteTemplateWithResponse is DeleteTemplate that also returns Postmark's response,
+// including its Message, on success and on API errors alike.
+func (c *Client) DeleteTemplateWithResponse(ID uint64) (PostmarkResponse, error) {
+	return c.deleteTemplate(strconv.FormatUint(ID, 10), false)
+}
+
+func (c *Client) DeleteTemplateByAlias(alias string) error {
+	_, err := c.deleteTemplate(alias, false)
+	return err
+}
+
+// ForceDeleteTemplate deletes a template even when safe delete is enabled and the template is active.
+func (c *Client) ForceDeleteTemplate(idOrAlias string) error {
+	_, err := c.deleteTemplate(idOrAlias, true)
+	return err
+}
+
+func (c *Client) deleteTemplate(idOrAlias string, force bool) (PostmarkResponse, error) {
+	if c.safeDelete && !force {
+		template, err := c.fetchTemplate(idOrAlias)
+		if err != nil {
+			return PostmarkResponse{}, err
+		}
+		if template.Active {
+			return PostmarkResponse{}, fmt.Errorf("%w: %s", ErrTemplateActive, idOrAlias)
+		}
+	}
+
+	defer c.forgetTemplate(idOrAlias)
+	url := "/templates/" + neturl.PathEscape(idOrAlias)
 	var postmarkResponse PostmarkResponse
 	if err := c.doRequest("DELETE", url, nil, &postmarkResponse); err != nil {
-		return err
+		return PostmarkResponse{}, err
 	}
 
 	if postmarkResponse.ErrorCode != 0 {
-		return fmt.Errorf("failed to delete template: %s", postmarkResponse.Message)
+		return postmarkResponse, fmt.Errorf("failed to delete template: %w", &APIError{ErrorCode: postmarkResponse.ErrorCode, Message: postmarkResponse.Message})
 	}
 
-	return nil
+	return postmarkResponse, nil
 }
 
+// GetTemplates lists a page of templates, active and inactive alike. Postmark's list
+// endpoint has no status filter; use GetTemplatesByActive to keep only one kind.
 func (c *Client) GetTemplates(offset, count int) ([]PostmarkTemplateDetails, error) {
 	url := fmt.Sprintf("/templates?offset=%d&count=%d", offset, count)
 	var postmarkResponse PostmarkTemplateListResponse
@@ -153,14 +635,85 @@ func (c *Client) GetTemplates(offset, count int) ([]PostmarkTemplateDetails, err
 	return postmarkResponse.Templates, nil
 }
 
+// CountTemplates returns the number of templates on the server without listing them.
+func (c *Client) CountTemplates() (int, error) {
+	var postmarkResponse PostmarkTemplateListResponse
+	if err := c.doRequest("GET", "/templates?offset=0&count=1", nil, &postmarkResponse); err != nil {
+		return 0, err
+	}
+	return postmarkResponse.TotalCount, nil
+}
+
+// GetTemplatesByActive lists a page of templates keeping only those whose Active flag
+// matches. Postmark cannot filter on this, so a page may hold fewer than count templates.
+func (c *Client) GetTemplatesByActive(offset, count int, active bool) ([]PostmarkTemplateDetails, error) {
+	templates, err := c.GetTemplates(offset, count)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := templates[:0]
+	for _, template := range templates {
+		if template.Active == active {
+			filtered = append(filtered, template)
+		}
+	}
+	return filtered, nil
+}
+
+// prepareEmail applies client-level defaults to a copy of the email. Fields the
+// caller has set, such as From, are never overridden.
+func (c *Client) prepareEmail(email EmailRequest) EmailRequest {
+	if email.Tag == "" {
+		email.Tag = c.defaultTag
+	}
+	if email.From == "" && c.fromPool != nil {
+		email.From = c.fromPool.pick()
+	}
+	if c.autoTextBody && email.TextBody == "" && email.HtmlBody != "" {
+		email.TextBody = HTMLToText(email.HtmlBody)
+	}
+	if c.forceNoTracking {
+		email.TrackOpens, email.TrackLinks = noTracking()
+	}
+	return email
+}
+
+// noTracking returns the TrackOpens and TrackLinks values that switch all tracking off.
+func noTracking() (*bool, LinkTracking) {
+	trackOpens := false
+	return &trackOpens, LinkTrackingNone
+}
+
 func (c *Client) SendEmail(email EmailRequest) (EmailResponse, error) {
+	return c.Send(context.Background(), email)
+}
+
+// Send is SendEmail with a context, and implements Mailer.
+func (c *Client) Send(ctx context.Context, email EmailRequest) (EmailResponse, error) {
+	email = c.prepareEmail(email)
+	if err := c.checkEmail(email); err != nil {
+		return EmailResponse{}, err
+	}
+
 	var emailResponse EmailResponse
-	if err := c.doRequest("POST", "/email", email, &emailResponse); err != nil {
+	if err := c.doRequestContext(ctx, "POST", "/email", email, &emailResponse, withPriority(email.Priority)); err != nil {
 		return EmailResponse{}, err
 	}
+	emailResponse.Metadata = email.Metadata
+	emailResponse.Warnings = c.emailWarnings(email)
 	return emailResponse, nil
 }
 
+func (c *Client) SendSimpleEmail(from, to, subject, htmlBody string) (EmailResponse, error) {
+	return c.SendEmail(EmailRequest{
+		From:     from,
+		To:       to,
+		Subject:  subject,
+		HtmlBody: htmlBody,
+	})
+}
+
 func main() {
 	client := NewClient("SERVER TOKEN")
 
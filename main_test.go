@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDecodeEmailResponseMessageID(t *testing.T) {
+	const messageID = "b7bc2f4a-e38e-4336-af7d-e6c392c2f817"
+	var emailResponse EmailResponse
+	err := json.Unmarshal([]byte(`{"To":"receiver@example.com","SubmittedAt":"2014-02-17T07:25:01.4178645-05:00","MessageID":"`+messageID+`","ErrorCode":0,"Message":"OK"}`), &emailResponse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if emailResponse.MessageID != messageID {
+		t.Errorf("MessageID = %q, want %q", emailResponse.MessageID, messageID)
+	}
+}
+
+func TestDecodeTemplateID(t *testing.T) {
+	var template PostmarkTemplateResponse
+	if err := json.Unmarshal([]byte(`{"TemplateId":1234567,"Name":"Welcome","AssociatedServerId":42,"Active":true}`), &template); err != nil {
+		t.Fatal(err)
+	}
+	if template.TemplateID != 1234567 {
+		t.Errorf("PostmarkTemplateResponse.TemplateID = %d, want 1234567", template.TemplateID)
+	}
+
+	var postmarkResponse PostmarkResponse
+	if err := json.Unmarshal([]byte(`{"TemplateId":1234567,"Name":"Welcome","Active":true}`), &postmarkResponse); err != nil {
+		t.Fatal(err)
+	}
+	if postmarkResponse.TemplateID != 1234567 {
+		t.Errorf("PostmarkResponse.TemplateID = %d, want 1234567", postmarkResponse.TemplateID)
+	}
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/email":
+			fmt.Fprint(w, `{"MessageID":"id","ErrorCode":0,"Message":"OK"}`)
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/templates/"):
+			fmt.Fprint(w, `{"TemplateId":7,"Name":"Welcome","Alias":"welcome","Active":true}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("token",
+		WithBaseURL(server.URL),
+		WithMaxConcurrency(4),
+		WithCircuitBreaker(5, time.Second),
+		WithFromPool([]string{"a@example.com", "b@example.com"}),
+		WithTemplateCache(time.Minute),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.SendEmail(EmailRequest{To: "r@example.com", Subject: "s", TextBody: "b"}); err != nil {
+				t.Errorf("SendEmail: %v", err)
+			}
+			if _, err := client.GetTemplate("welcome"); err != nil {
+				t.Errorf("GetTemplate: %v", err)
+			}
+			if ID, err := client.ResolveTemplateAlias("welcome"); err != nil || ID != 7 {
+				t.Errorf("ResolveTemplateAlias = %d, %v", ID, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDoRawSendsRawBodyWithItsContentType(t *testing.T) {
+	var contentType, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	resp, err := client.DoRaw(context.Background(), "POST", "/raw", RawBody{ContentType: "text/plain", Data: []byte("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if contentType != "text/plain" || body != "hello" {
+		t.Errorf("server got Content-Type %q and body %q", contentType, body)
+	}
+
+	resp, err = client.DoRaw(context.Background(), "POST", "/json", map[string]string{"Name": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if contentType != jsonContentType || body != `{"Name":"x"}` {
+		t.Errorf("server got Content-Type %q and body %q", contentType, body)
+	}
+}
+
+func TestCircuitBreakerCountsTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient("token", WithBaseURL(server.URL), WithCircuitBreaker(2, time.Minute))
+	email := EmailRequest{From: "s@example.com", To: "r@example.com", Subject: "s", TextBody: "b"}
+
+	timeout := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := client.Send(ctx, email); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Send = %v, want a deadline error", err)
+		}
+	}
+
+	timeout()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Send(cancelled, email); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Send = %v, want a cancellation error", err)
+	}
+	timeout()
+	if _, err := client.Send(context.Background(), email); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Send after two timeouts = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestDoRawHoldsConcurrencySlotUntilClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"MessageID":"id","ErrorCode":0,"Message":"OK"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL), WithMaxConcurrency(1))
+	email := EmailRequest{From: "s@example.com", To: "r@example.com", Subject: "s", TextBody: "b"}
+	resp, err := client.DoRaw(context.Background(), "GET", "/raw", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Send(ctx, email); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Send while DoRaw body is open = %v, want a deadline error", err)
+	}
+
+	resp.Body.Close()
+	resp.Body.Close()
+	if _, err := client.Send(context.Background(), email); err != nil {
+		t.Fatalf("Send after closing DoRaw body: %v", err)
+	}
+	if client.limiter.inUse != 0 {
+		t.Errorf("limiter has %d slots in use, want 0", client.limiter.inUse)
+	}
+}
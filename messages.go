@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultOutboundMessagePageSize = 500
+
+// OutboundMessageQuery represents the filters for searching outbound messages.
+// Dates use the YYYY-MM-DD or YYYY-MM-DDThh:mm:ss format.
+type OutboundMessageQuery struct {
+	Count         int
+	Offset        int
+	Recipient     string
+	FromEmail     string
+	Tag           string
+	Status        string
+	Subject       string
+	MessageStream string
+	FromDate      string
+	ToDate        string
+}
+
+// OutboundMessagesResponse represents the response from Postmark API for outbound message search
+type OutboundMessagesResponse struct {
+	TotalCount int               `json:"TotalCount"`
+	Messages   []OutboundMessage `json:"Messages"`
+}
+
+// OutboundMessage represents a single sent message
+type OutboundMessage struct {
+	MessageID     string            `json:"MessageID"`
+	MessageStream string            `json:"MessageStream"`
+	Tag           string            `json:"Tag"`
+	From          string            `json:"From"`
+	To            []Recipient       `json:"To"`
+	Cc            []Recipient       `json:"Cc"`
+	Bcc           []Recipient       `json:"Bcc"`
+	Recipients    []string          `json:"Recipients"`
+	ReceivedAt    string            `json:"ReceivedAt"`
+	Subject       string            `json:"Subject"`
+	Attachments   []string          `json:"Attachments"`
+	Status        string            `json:"Status"`
+	TrackOpens    bool              `json:"TrackOpens"`
+	TrackLinks    string            `json:"TrackLinks"`
+	Metadata      map[string]string `json:"Metadata"`
+}
+
+// Recipient represents an addressee of a message
+type Recipient struct {
+	Email string `json:"Email"`
+	Name  string `json:"Name"`
+}
+
+func (q OutboundMessageQuery) values() url.Values {
+	values := url.Values{}
+	values.Set("count", strconv.Itoa(q.Count))
+	values.Set("offset", strconv.Itoa(q.Offset))
+	if q.Recipient != "" {
+		values.Set("recipient", q.Recipient)
+	}
+	if q.FromEmail != "" {
+		values.Set("fromemail", q.FromEmail)
+	}
+	if q.Tag != "" {
+		values.Set("tag", q.Tag)
+	}
+	if q.Status != "" {
+		values.Set("status", q.Status)
+	}
+	if q.Subject != "" {
+		values.Set("subject", q.Subject)
+	}
+	if q.MessageStream != "" {
+		values.Set("messagestream", q.MessageStream)
+	}
+	if q.FromDate != "" {
+		values.Set("fromdate", q.FromDate)
+	}
+	if q.ToDate != "" {
+		values.Set("todate", q.ToDate)
+	}
+	return values
+}
+
+func (c *Client) GetOutboundMessages(query OutboundMessageQuery) ([]OutboundMessage, error) {
+	return c.getOutboundMessages(context.Background(), query)
+}
+
+func (c *Client) getOutboundMessages(ctx context.Context, query OutboundMessageQuery) ([]OutboundMessage, error) {
+	var messagesResponse OutboundMessagesResponse
+	if err := c.doRequestContext(ctx, "GET", "/messages/outbound?"+query.values().Encode(), nil, &messagesResponse); err != nil {
+		return nil, err
+	}
+	return messagesResponse.Messages, nil
+}
+
+// OutboundMessageIterator pages through outbound messages one page at a time. Postmark
+// will not page a search deeper than 10,000 messages, so past that the iterator narrows
+// ToDate to the oldest message seen and carries on from there; it fails with
+// ErrSearchDepth only if more than 10,000 messages share one second.
+// An iterator must not be shared between goroutines.
+type OutboundMessageIterator struct {
+	client *Client
+	query  OutboundMessageQuery
+	cursor searchCursor
+	page   []OutboundMessage
+	pos    int
+	done   bool
+}
+
+func (c *Client) NewOutboundMessageIterator(query OutboundMessageQuery) *OutboundMessageIterator {
+	if query.Count <= 0 {
+		query.Count = defaultOutboundMessagePageSize
+	}
+	return &OutboundMessageIterator{client: c, query: query}
+}
+
+// Next returns the next message, fetching the following page when the current one is exhausted.
+// The boolean is false once there are no more messages.
+func (it *OutboundMessageIterator) Next(ctx context.Context) (OutboundMessage, bool, error) {
+	for it.pos >= len(it.page) {
+		if it.done {
+			return OutboundMessage{}, false, nil
+		}
+		if err := it.cursor.advance(&it.query.ToDate, &it.query.Offset, it.query.Count); err != nil {
+			return OutboundMessage{}, false, err
+		}
+
+		page, err := it.client.getOutboundMessages(ctx, it.query)
+		if err != nil {
+			return OutboundMessage{}, false, err
+		}
+
+		it.query.Offset += len(page)
+		if len(page) < it.query.Count {
+			it.done = true
+		}
+		it.page = it.page[:0]
+		it.pos = 0
+		for _, message := range page {
+			if !it.cursor.skip(message.MessageID, message.ReceivedAt) {
+				it.page = append(it.page, message)
+			}
+		}
+	}
+
+	message := it.page[it.pos]
+	it.pos++
+	return message, true, nil
+}
+
+// OutboundMessageDetails represents a sent message along with its delivery events
+type OutboundMessageDetails struct {
+	OutboundMessage
+	TextBody      string         `json:"TextBody"`
+	HtmlBody      string         `json:"HtmlBody"`
+	Body          string         `json:"Body"`
+	MessageEvents []MessageEvent `json:"MessageEvents"`
+}
+
+// MessageEvent represents something that happened to a sent message
+type MessageEvent struct {
+	Recipient  string            `json:"Recipient"`
+	Type       string            `json:"Type"`
+	ReceivedAt string            `json:"ReceivedAt"`
+	Details    map[string]string `json:"Details"`
+}
+
+func (c *Client) GetOutboundMessageDetails(messageID string) (OutboundMessageDetails, error) {
+	return c.getOutboundMessageDetails(context.Background(), messageID)
+}
+
+func (c *Client) getOutboundMessageDetails(ctx context.Context, messageID string) (OutboundMessageDetails, error) {
+	var details OutboundMessageDetails
+	if err := c.doRequestContext(ctx, "GET", "/messages/outbound/"+url.PathEscape(messageID)+"/details", nil, &details); err != nil {
+		return OutboundMessageDetails{}, err
+	}
+	return details, nil
+}
+
+// ResendMessage sends a new copy of a previously sent message, to the original To, Cc
+// and Bcc recipients or, when to is set, to that address only. Attachments are not
+// included in the message details Postmark returns, so they are not resent.
+func (c *Client) ResendMessage(messageID string, to string) (EmailResponse, error) {
+	details, err := c.GetOutboundMessageDetails(messageID)
+	if err != nil {
+		return EmailResponse{}, fmt.Errorf("failed to fetch message %s: %w", messageID, err)
+	}
+
+	email := EmailRequest{
+		From:          details.From,
+		To:            to,
+		Subject:       details.Subject,
+		HtmlBody:      details.HtmlBody,
+		TextBody:      details.TextBody,
+		Tag:           details.Tag,
+		Metadata:      details.Metadata,
+		MessageStream: details.MessageStream,
+	}
+	if to == "" {
+		email.To = joinRecipients(details.To)
+		email.Cc = joinRecipients(details.Cc)
+		email.Bcc = joinRecipients(details.Bcc)
+	}
+	return c.SendEmail(email)
+}
+
+func joinRecipients(recipients []Recipient) string {
+	addresses := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		if recipient.Name == "" {
+			addresses[i] = recipient.Email
+			continue
+		}
+		addresses[i] = (&mail.Address{Name: recipient.Name, Address: recipient.Email}).String()
+	}
+	return strings.Join(addresses, ",")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOutboundMessageIteratorPastSearchDepth(t *testing.T) {
+	const total = 12345
+	server := newOutboundMessageServer(t, total)
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	it := client.NewOutboundMessageIterator(OutboundMessageQuery{})
+	seen := make(map[string]bool)
+	for {
+		message, ok, err := it.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next after %d messages: %v", len(seen), err)
+		}
+		if !ok {
+			break
+		}
+		if seen[message.MessageID] {
+			t.Fatalf("message %s returned twice", message.MessageID)
+		}
+		seen[message.MessageID] = true
+	}
+	if len(seen) != total {
+		t.Fatalf("got %d messages, want %d", len(seen), total)
+	}
+}
+
+func TestGetMessageTimelineOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/messages/outbound/m/details":
+			fmt.Fprint(w, `{"ReceivedAt":"2024-05-01T10:00:00.1234567-04:00","MessageEvents":[
+				{"Type":"Opened","ReceivedAt":"2024-05-01T10:05:00.5-04:00"},
+				{"Type":"Unknown","ReceivedAt":"soon"},
+				{"Type":"Delivered","ReceivedAt":"2024-05-01T10:00:03-04:00"}]}`)
+		case "/bounces":
+			fmt.Fprint(w, `{"TotalCount":0,"Bounces":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	events, err := client.GetMessageTimeline("m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Submitted", "Delivered", "Opened", "Unknown"}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, event := range events {
+		if event.Type != want[i] {
+			t.Errorf("event %d = %s, want %s", i, event.Type, want[i])
+		}
+	}
+}
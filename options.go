@@ -0,0 +1,275 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RegionUS is the region served by Postmark's default API endpoint
+const RegionUS = "us"
+
+var regionBaseURLs = map[string]string{
+	RegionUS: defaultBaseURL,
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithMaxBodySize sets the largest request body, in bytes, the client will send
+func WithMaxBodySize(size int) Option {
+	return func(c *Client) {
+		c.maxBodySize = size
+	}
+}
+
+// WithDefaultTag sets the tag applied to outgoing emails that do not specify one
+func WithDefaultTag(tag string) Option {
+	return func(c *Client) {
+		c.defaultTag = tag
+	}
+}
+
+// WithRetry retries rate-limited, server-error and network failures up to maxRetries
+// times, doubling the delay after each attempt starting from baseDelay
+func WithRetry(maxRetries int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryBase = baseDelay
+	}
+}
+
+// WithAutoTextBody fills in TextBody from HtmlBody when an email only has HTML content
+func WithAutoTextBody() Option {
+	return func(c *Client) {
+		c.autoTextBody = true
+	}
+}
+
+// WithSafeDelete makes DeleteTemplate, DeleteTemplateWithResponse and DeleteTemplateByAlias
+// refuse to remove active templates; ForceDeleteTemplate bypasses the check
+func WithSafeDelete() Option {
+	return func(c *Client) {
+		c.safeDelete = true
+	}
+}
+
+// WithBaseURL overrides the Postmark API endpoint
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithRegion pins the client to the API endpoint of a named region. Postmark currently
+// serves every account from its US endpoint, so any other region is reported as an
+// error on the first request rather than silently falling back.
+func WithRegion(region string) Option {
+	return func(c *Client) {
+		baseURL, ok := regionBaseURLs[strings.ToLower(region)]
+		if !ok {
+			c.configErr = fmt.Errorf("%w: %q", ErrUnsupportedRegion, region)
+			return
+		}
+		c.baseURL = baseURL
+	}
+}
+
+// WithHistoryStore records the previous content of a template on every UpdateTemplate
+func WithHistoryStore(store HistoryStore) Option {
+	return func(c *Client) {
+		c.history = store
+	}
+}
+
+// WithResponseHook calls hook with the endpoint and decoded result of every successful request
+func WithResponseHook(hook func(endpoint string, result interface{})) Option {
+	return func(c *Client) {
+		c.responseHook = hook
+	}
+}
+
+// WithCircuitBreaker fails requests immediately with ErrCircuitOpen for cooldown after
+// threshold consecutive network, rate-limit or server errors
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.breaker = &circuitBreaker{threshold: threshold, cooldown: cooldown}
+	}
+}
+
+// WithCodec replaces encoding/json for request and response bodies
+func WithCodec(codec Codec) Option {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// WithMaxConcurrency caps the number of requests in flight at once; further requests
+// wait for a slot, and emails with a higher EmailRequest.Priority are let through first.
+// n <= 0 means no limit.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Client) {
+		if n <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = newLimiter(n)
+	}
+}
+
+// WithRetryJitter randomizes each WithRetry backoff between zero and the computed delay
+func WithRetryJitter() Option {
+	return func(c *Client) {
+		c.retryJitter = true
+	}
+}
+
+// WithFromPool rotates through addrs, round-robin, for emails sent without a From
+func WithFromPool(addrs []string) Option {
+	return func(c *Client) {
+		if len(addrs) > 0 {
+			c.fromPool = &fromPool{addrs: append([]string(nil), addrs...)}
+		}
+	}
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification.
+//
+// For tests only: it lets the client talk through a proxy with a self-signed
+// certificate, and must never be enabled against the real Postmark API.
+func WithInsecureSkipVerify() Option {
+	return func(c *Client) {
+		transport := c.transport()
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+}
+
+// WithTransportConfig tunes the connection pool: maxIdleConns and maxIdleConnsPerHost cap
+// the keep-alive connections held open, and idleTimeout closes ones left unused longer
+func WithTransportConfig(maxIdleConns, maxIdleConnsPerHost int, idleTimeout time.Duration) Option {
+	return func(c *Client) {
+		transport := c.transport()
+		transport.MaxIdleConns = maxIdleConns
+		transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		transport.IdleConnTimeout = idleTimeout
+	}
+}
+
+// transport returns the client's own *http.Transport, creating one from the default
+// transport the first time an option needs to tune it.
+func (c *Client) transport() *http.Transport {
+	if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
+		return transport
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	c.httpClient.Transport = transport
+	return transport
+}
+
+// WithTemplateCache serves GetTemplate and ResolveTemplateAlias from memory for ttl
+// after a template is first fetched. Changes made through the client evict the
+// affected template; changes made elsewhere show up once the entry expires.
+func WithTemplateCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.templateCache = newTemplateCache(ttl)
+	}
+}
+
+// WithAllowedFromDomains rejects, before sending, any email whose From address is not
+// on one of the given domains
+func WithAllowedFromDomains(domains []string) Option {
+	return func(c *Client) {
+		c.allowedFromDomains = make(map[string]bool, len(domains))
+		for _, domain := range domains {
+			c.allowedFromDomains[strings.ToLower(domain)] = true
+		}
+	}
+}
+
+// WithAutoSuppress drops recipients suppressed on streamID from batch messages sent on
+// that stream. The suppression list is fetched once and refreshed every five minutes.
+func WithAutoSuppress(streamID string) Option {
+	return func(c *Client) {
+		c.autoSuppress = &suppressionCache{streamID: streamID, ttl: defaultSuppressionCacheTTL}
+	}
+}
+
+// WithStreamTypes declares the type of each message stream the client sends on, keyed by
+// stream ID. Emails sent on a broadcast stream without an unsubscribe header or link, and
+// emails sent on an inbound stream, are rejected before sending.
+func WithStreamTypes(types map[string]MessageStreamType) Option {
+	return func(c *Client) {
+		c.streamTypes = make(map[string]MessageStreamType, len(types))
+		for id, streamType := range types {
+			c.streamTypes[id] = streamType
+		}
+	}
+}
+
+// WithMaxResponseSize fails requests whose response body is larger than n bytes instead
+// of reading it into memory
+func WithMaxResponseSize(n int64) Option {
+	return func(c *Client) {
+		c.maxResponseSize = n
+	}
+}
+
+// WithForceNoTracking sends every email with TrackOpens false and TrackLinks None,
+// overriding both the email's own settings and the server and template defaults
+func WithForceNoTracking() Option {
+	return func(c *Client) {
+		c.forceNoTracking = true
+	}
+}
+
+// WithDoer sends requests through doer instead of the client's own *http.Client, for
+// example a RecordingTransport in tests. Transport options such as
+// WithInsecureSkipVerify then have no effect.
+func WithDoer(doer Doer) Option {
+	return func(c *Client) {
+		c.doer = doer
+	}
+}
+
+// WithAccountToken sets the account API token used by account-level methods such as
+// GetServers; server-level methods keep using the server token
+func WithAccountToken(token string) Option {
+	return func(c *Client) {
+		c.accountToken = token
+	}
+}
+
+// WithMaxSubjectLength rejects, before sending, emails whose Subject is longer than n
+// octets. Subjects are always rejected past RFC 5322's 998 octet line limit, which is
+// also the default; n <= 0 or above 998 keeps that ceiling.
+func WithMaxSubjectLength(n int) Option {
+	return func(c *Client) {
+		if n <= 0 || n > maxSubjectLength {
+			n = maxSubjectLength
+		}
+		c.maxSubjectLength = n
+	}
+}
+
+// WithSubjectWarningLength sets the Subject length, in octets, past which a send is
+// flagged in EmailResponse.Warnings but still goes ahead. The default is 78, around
+// where mail clients cut subjects off; n <= 0 disables the warning.
+func WithSubjectWarningLength(n int) Option {
+	return func(c *Client) {
+		c.subjectWarnLength = n
+	}
+}
+
+// WithRetryIf also retries failed responses for which retryIf returns true, on top of the
+// rate-limit and server errors WithRetry always retries. It is called with the response
+// and its *StatusError; the response body has already been read into the error.
+func WithRetryIf(retryIf func(resp *http.Response, err error) bool) Option {
+	return func(c *Client) {
+		c.retryIf = retryIf
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithMaxConcurrencyZeroIsUnlimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"MessageID":"id","ErrorCode":0,"Message":"OK"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL), WithMaxConcurrency(0))
+	if _, err := client.SendEmail(EmailRequest{From: "s@example.com", To: "r@example.com", Subject: "s", TextBody: "b"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxSearchDepth is the deepest Postmark lets a search page: count + offset may not exceed it.
+const maxSearchDepth = 10000
+
+// searchDateLayout is the date/time format the search endpoints accept for fromdate and todate.
+const searchDateLayout = "2006-01-02T15:04:05"
+
+// searchCursor lets an iterator walk a search past maxSearchDepth. Searches return the
+// newest results first, so once the depth is used up the iterator narrows todate to the
+// oldest result seen and starts again from offset zero. todate only has one-second
+// precision, so results from the boundary second come back again; the cursor remembers
+// those and skips them.
+type searchCursor struct {
+	oldest time.Time
+	seen   map[string]time.Time
+}
+
+// skip records a result and reports whether it was already returned. Results with an
+// unparseable timestamp are never skipped.
+func (sc *searchCursor) skip(ID, timestamp string) bool {
+	at, err := parseTimestamp(timestamp)
+	if err != nil {
+		return false
+	}
+	if _, ok := sc.seen[ID]; ok {
+		return true
+	}
+	if sc.seen == nil {
+		sc.seen = make(map[string]time.Time)
+	}
+
+	second := at.Truncate(time.Second)
+	if sc.oldest.IsZero() || second.Before(sc.oldest) {
+		sc.oldest = second
+		// Only the boundary second and the one after it can be fetched again.
+		for seenID, seenAt := range sc.seen {
+			if seenAt.After(second.Add(time.Second)) {
+				delete(sc.seen, seenID)
+			}
+		}
+	}
+	sc.seen[ID] = second
+	return false
+}
+
+// advance moves to a new date window when the next page would pass maxSearchDepth,
+// updating toDate and offset in place.
+func (sc *searchCursor) advance(toDate *string, offset *int, count int) error {
+	if *offset+count <= maxSearchDepth {
+		return nil
+	}
+	if sc.oldest.IsZero() {
+		return fmt.Errorf("%w: offset %d", ErrSearchDepth, *offset)
+	}
+
+	// Include the whole boundary second whether or not todate is inclusive.
+	boundary := sc.oldest.Add(time.Second).Format(searchDateLayout)
+	if boundary == *toDate {
+		return fmt.Errorf("%w: more than %d results before %s", ErrSearchDepth, maxSearchDepth, boundary)
+	}
+	*toDate = boundary
+	*offset = 0
+	return nil
+}
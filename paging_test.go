@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// searchNewest is the timestamp of the newest result served by newSearchServer fixtures.
+var searchNewest = time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("EDT", -4*60*60))
+
+// newSearchHandler serves items, which must be ordered newest first, the way Postmark's
+// search endpoints do: it enforces the count + offset search depth limit and an
+// inclusive, one-second precision todate. at returns an item's timestamp and respond
+// wraps a page in the endpoint's response body.
+func newSearchHandler[T any](t *testing.T, items []T, at func(T) string, respond func(total int, page []T) any) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		count, _ := strconv.Atoi(query.Get("count"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		if count+offset > maxSearchDepth {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"ErrorCode":300,"Message":"count + offset exceeds 10000"}`)
+			return
+		}
+
+		matching := items
+		if toDate := query.Get("todate"); toDate != "" {
+			limit, err := time.ParseInLocation(searchDateLayout, toDate, searchNewest.Location())
+			if err != nil {
+				t.Errorf("bad todate %q", toDate)
+			}
+			matching = nil
+			for _, item := range items {
+				itemAt, _ := time.Parse(time.RFC3339Nano, at(item))
+				if !itemAt.Truncate(time.Second).After(limit) {
+					matching = append(matching, item)
+				}
+			}
+		}
+
+		page := matching[min(offset, len(matching)):min(offset+count, len(matching))]
+		json.NewEncoder(w).Encode(respond(len(matching), page))
+	}
+}
+
+// newBounceServer serves total bounces, newest first, two to a second, tagged "even"
+// or "odd" and alternately inactive.
+func newBounceServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(newBounceHandler(t, total))
+}
+
+func newBounceHandler(t *testing.T, total int) http.HandlerFunc {
+	t.Helper()
+	bounces := make([]Bounce, total)
+	for i := range bounces {
+		at := searchNewest.Add(-time.Duration(i/2) * time.Second)
+		bounces[i] = Bounce{
+			ID:          int64(i + 1),
+			Tag:         []string{"even", "odd"}[i%2],
+			Email:       "r@example.com",
+			Inactive:    i%2 == 0,
+			CanActivate: true,
+			BouncedAt:   at.Format(time.RFC3339Nano),
+		}
+	}
+	return newSearchHandler(t, bounces,
+		func(bounce Bounce) string { return bounce.BouncedAt },
+		func(total int, page []Bounce) any { return BouncesResponse{TotalCount: total, Bounces: page} })
+}
+
+// newOutboundMessageServer serves total outbound messages, newest first, three to a second.
+func newOutboundMessageServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	messages := make([]OutboundMessage, total)
+	for i := range messages {
+		at := searchNewest.Add(-time.Duration(i/3)*time.Second - time.Duration(i%3)*time.Millisecond)
+		messages[i] = OutboundMessage{MessageID: strconv.Itoa(i), ReceivedAt: at.Format(time.RFC3339Nano)}
+	}
+	return httptest.NewServer(newSearchHandler(t, messages,
+		func(message OutboundMessage) string { return message.ReceivedAt },
+		func(total int, page []OutboundMessage) any {
+			return OutboundMessagesResponse{TotalCount: total, Messages: page}
+		}))
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// isRetryableStatus reports whether a response status is transient. Rate limiting and
+// server errors may succeed on a later attempt; anything else (bad token, forbidden,
+// unprocessable request) will fail the same way every time.
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// retryDelay returns the exponential backoff before the given retry attempt. With
+// jitter enabled the delay is drawn uniformly between zero and that backoff, so
+// clients that failed together do not all retry together.
+func (c *Client) retryDelay(attempt int) time.Duration {
+	delay := c.retryBase << attempt
+	if c.retryJitter && delay > 0 {
+		return rand.N(delay + 1)
+	}
+	return delay
+}
+
+// shouldRetry reports whether a failed response is worth retrying, either because its
+// status is transient or because the WithRetryIf predicate says so.
+func (c *Client) shouldRetry(resp *http.Response, err error) bool {
+	return isRetryableStatus(resp.StatusCode) || (c.retryIf != nil && c.retryIf(resp, err))
+}
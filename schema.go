@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// modelSchema is the subset of JSON Schema understood by ValidateModel
+type modelSchema struct {
+	Type                 schemaTypes             `json:"type"`
+	Properties           map[string]*modelSchema `json:"properties"`
+	Required             []string                `json:"required"`
+	Items                *modelSchema            `json:"items"`
+	Enum                 []interface{}           `json:"enum"`
+	AdditionalProperties *bool                   `json:"additionalProperties"`
+}
+
+// schemaTypes accepts both "type": "string" and "type": ["string", "null"]
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings: %w", err)
+	}
+	*t = multiple
+	return nil
+}
+
+// ValidateModel checks a template model against a JSON schema. The supported keywords
+// are type, properties, required, items, enum and a boolean additionalProperties.
+// Every violation is reported in the returned error, which wraps ErrInvalidModel.
+func ValidateModel(model map[string]interface{}, schema []byte) error {
+	var root modelSchema
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	// Round-trip the model so values have the same types as decoded JSON.
+	data, err := json.Marshal(model)
+	if err != nil {
+		return fmt.Errorf("failed to marshal model: %w", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode model: %w", err)
+	}
+
+	if problems := root.validate("", value); len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidModel, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (s *modelSchema) validate(path string, value interface{}) []string {
+	if len(s.Type) > 0 && !s.Type.matches(value) {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", schemaPath(path), strings.Join(s.Type, " or "), jsonType(value))}
+	}
+
+	var problems []string
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		problems = append(problems, fmt.Sprintf("%s: value is not one of the allowed values", schemaPath(path)))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing required field", path+"/"+name))
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := s.Properties[name]; ok {
+				problems = append(problems, property.validate(path+"/"+name, v[name])...)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				problems = append(problems, fmt.Sprintf("%s: unexpected field", path+"/"+name))
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				problems = append(problems, s.Items.validate(fmt.Sprintf("%s/%d", path, i), item)...)
+			}
+		}
+	}
+	return problems
+}
+
+func (t schemaTypes) matches(value interface{}) bool {
+	actual := jsonType(value)
+	for _, expected := range t {
+		if expected == actual || (expected == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// Server represents a Postmark server
+type Server struct {
+	ID                         int64        `json:"ID"`
+	Name                       string       `json:"Name"`
+	ApiTokens                  []string     `json:"ApiTokens"`
+	Color                      string       `json:"Color"`
+	ServerLink                 string       `json:"ServerLink"`
+	DeliveryType               string       `json:"DeliveryType"`
+	SmtpApiActivated           bool         `json:"SmtpApiActivated"`
+	RawEmailEnabled            bool         `json:"RawEmailEnabled"`
+	TrackOpens                 bool         `json:"TrackOpens"`
+	TrackLinks                 LinkTracking `json:"TrackLinks"`
+	PostFirstOpenOnly          bool         `json:"PostFirstOpenOnly"`
+	IncludeBounceContentInHook bool         `json:"IncludeBounceContentInHook"`
+	EnableSmtpApiErrorHooks    bool         `json:"EnableSmtpApiErrorHooks"`
+	InboundAddress             string       `json:"InboundAddress"`
+	InboundDomain              string       `json:"InboundDomain"`
+	InboundHash                string       `json:"InboundHash"`
+	InboundSpamThreshold       int          `json:"InboundSpamThreshold"`
+	InboundHookUrl             string       `json:"InboundHookUrl"`
+	BounceHookUrl              string       `json:"BounceHookUrl"`
+	OpenHookUrl                string       `json:"OpenHookUrl"`
+	DeliveryHookUrl            string       `json:"DeliveryHookUrl"`
+	ClickHookUrl               string       `json:"ClickHookUrl"`
+}
+
+// GetServer returns the server the client's token belongs to
+func (c *Client) GetServer() (Server, error) {
+	return c.getServer(context.Background())
+}
+
+func (c *Client) getServer(ctx context.Context) (Server, error) {
+	var server Server
+	if err := c.doRequestContext(ctx, "GET", "/server", nil, &server); err != nil {
+		return Server{}, err
+	}
+	return server, nil
+}
+
+// ServersResponse represents the response from Postmark API for listing servers
+type ServersResponse struct {
+	TotalCount int      `json:"TotalCount"`
+	Servers    []Server `json:"Servers"`
+}
+
+// GetServers lists the servers on the account. It requires WithAccountToken.
+func (c *Client) GetServers(offset, count int) ([]Server, error) {
+	url := fmt.Sprintf("/servers?offset=%d&count=%d", offset, count)
+	var serversResponse ServersResponse
+	if err := c.doRequest("GET", url, nil, &serversResponse, withAccountToken()); err != nil {
+		return nil, err
+	}
+	return serversResponse.Servers, nil
+}
+
+// ServerCreate represents the request body for creating a server. Unset fields take
+// Postmark's defaults.
+type ServerCreate struct {
+	Name                    string       `json:"Name"`
+	Color                   string       `json:"Color,omitempty"`
+	DeliveryType            string       `json:"DeliveryType,omitempty"`
+	SmtpApiActivated        *bool        `json:"SmtpApiActivated,omitempty"`
+	RawEmailEnabled         bool         `json:"RawEmailEnabled,omitempty"`
+	TrackOpens              bool         `json:"TrackOpens,omitempty"`
+	TrackLinks              LinkTracking `json:"TrackLinks,omitempty"`
+	PostFirstOpenOnly       bool         `json:"PostFirstOpenOnly,omitempty"`
+	EnableSmtpApiErrorHooks bool         `json:"EnableSmtpApiErrorHooks,omitempty"`
+	InboundDomain           string       `json:"InboundDomain,omitempty"`
+	InboundSpamThreshold    int          `json:"InboundSpamThreshold,omitempty"`
+	InboundHookUrl          string       `json:"InboundHookUrl,omitempty"`
+	BounceHookUrl           string       `json:"BounceHookUrl,omitempty"`
+	OpenHookUrl             string       `json:"OpenHookUrl,omitempty"`
+	DeliveryHookUrl         string       `json:"DeliveryHookUrl,omitempty"`
+	ClickHookUrl            string       `json:"ClickHookUrl,omitempty"`
+}
+
+// CreateServer creates a server on the account and returns it, including the ApiTokens
+// a client for the new server needs. It requires WithAccountToken.
+func (c *Client) CreateServer(req ServerCreate) (Server, error) {
+	var server Server
+	if err := c.doRequest("POST", "/servers", req, &server, withAccountToken()); err != nil {
+		return Server{}, err
+	}
+	return server, nil
+}
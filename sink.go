@@ -0,0 +1,28 @@
+package main
+
+// BlackholeAddress is Postmark's sink address: mail to it is accepted and processed
+// like any other message but never delivered to an inbox.
+const BlackholeAddress = "test@blackhole.postmarkapp.com"
+
+// SendEmailToSink sends count copies of email to BlackholeAddress, in batches, for load
+// testing. Any To, Cc and Bcc on email are replaced so no real inbox is reached.
+func (c *Client) SendEmailToSink(email EmailRequest, count int) ([]EmailResponse, error) {
+	email.To = BlackholeAddress
+	email.Cc = ""
+	email.Bcc = ""
+
+	emailResponses := make([]EmailResponse, 0, count)
+	for sent := 0; sent < count; sent += maxBatchSize {
+		batch := make([]EmailRequest, min(maxBatchSize, count-sent))
+		for i := range batch {
+			batch[i] = email
+		}
+
+		batchResponses, err := c.SendEmailBatch(batch)
+		if err != nil && batchResponses == nil {
+			return emailResponses, err
+		}
+		emailResponses = append(emailResponses, batchResponses...)
+	}
+	return emailResponses, batchError(emailResponses)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SentCountsResponse represents the response from Postmark API for sent counts
+type SentCountsResponse struct {
+	Days []SentCountDay `json:"Days"`
+	Sent int            `json:"Sent"`
+}
+
+// SentCountDay represents the sent count for a single day
+type SentCountDay struct {
+	Date string `json:"Date"`
+	Sent int    `json:"Sent"`
+}
+
+// Usage represents the number of messages sent within a period
+type Usage struct {
+	FromDate time.Time
+	ToDate   time.Time
+	Sent     int
+}
+
+// GetMonthlyUsage totals the messages sent since the start of the current month
+func (c *Client) GetMonthlyUsage() (Usage, error) {
+	now := time.Now()
+	fromDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	url := fmt.Sprintf("/stats/outbound/sends?fromdate=%s&todate=%s",
+		fromDate.Format("2006-01-02"), now.Format("2006-01-02"))
+	var sentCounts SentCountsResponse
+	if err := c.doRequest("GET", url, nil, &sentCounts); err != nil {
+		return Usage{}, err
+	}
+
+	return Usage{
+		FromDate: fromDate,
+		ToDate:   now,
+		Sent:     sentCounts.Sent,
+	}, nil
+}
+
+// StatsQuery represents the filters accepted by the stats endpoints.
+// Dates use the YYYY-MM-DD format.
+type StatsQuery struct {
+	Tag           string
+	FromDate      string
+	ToDate        string
+	MessageStream string
+}
+
+func (q StatsQuery) values() url.Values {
+	values := url.Values{}
+	if q.Tag != "" {
+		values.Set("tag", q.Tag)
+	}
+	if q.FromDate != "" {
+		values.Set("fromdate", q.FromDate)
+	}
+	if q.ToDate != "" {
+		values.Set("todate", q.ToDate)
+	}
+	if q.MessageStream != "" {
+		values.Set("messagestream", q.MessageStream)
+	}
+	return values
+}
+
+// OutboundStats represents the outbound overview statistics
+type OutboundStats struct {
+	Sent                  int     `json:"Sent"`
+	Bounced               int     `json:"Bounced"`
+	SMTPApiErrors         int     `json:"SMTPApiErrors"`
+	BounceRate            float64 `json:"BounceRate"`
+	SpamComplaints        int     `json:"SpamComplaints"`
+	SpamComplaintsRate    float64 `json:"SpamComplaintsRate"`
+	Opens                 int     `json:"Opens"`
+	UniqueOpens           int     `json:"UniqueOpens"`
+	Tracked               int     `json:"Tracked"`
+	WithLinkTracking      int     `json:"WithLinkTracking"`
+	WithOpenTracking      int     `json:"WithOpenTracking"`
+	TotalTrackedLinksSent int     `json:"TotalTrackedLinksSent"`
+	UniqueLinksClicked    int     `json:"UniqueLinksClicked"`
+	TotalClicks           int     `json:"TotalClicks"`
+}
+
+func (c *Client) GetOutboundStats(query StatsQuery) (OutboundStats, error) {
+	var stats OutboundStats
+	if err := c.doRequest("GET", "/stats/outbound?"+query.values().Encode(), nil, &stats); err != nil {
+		return OutboundStats{}, err
+	}
+	return stats, nil
+}
+
+// GetStatsByTag returns the outbound overview for messages sent with the given tag
+func (c *Client) GetStatsByTag(tag string) (OutboundStats, error) {
+	return c.GetOutboundStats(StatsQuery{Tag: tag})
+}
+
+// ExportStatsCSV writes the outbound overview for the query as Metric,Value rows
+func (c *Client) ExportStatsCSV(w io.Writer, query StatsQuery) error {
+	stats, err := c.GetOutboundStats(query)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{
+		{"Metric", "Value"},
+		{"Sent", strconv.Itoa(stats.Sent)},
+		{"Bounced", strconv.Itoa(stats.Bounced)},
+		{"SMTPApiErrors", strconv.Itoa(stats.SMTPApiErrors)},
+		{"BounceRate", strconv.FormatFloat(stats.BounceRate, 'f', -1, 64)},
+		{"SpamComplaints", strconv.Itoa(stats.SpamComplaints)},
+		{"SpamComplaintsRate", strconv.FormatFloat(stats.SpamComplaintsRate, 'f', -1, 64)},
+		{"Opens", strconv.Itoa(stats.Opens)},
+		{"UniqueOpens", strconv.Itoa(stats.UniqueOpens)},
+		{"Tracked", strconv.Itoa(stats.Tracked)},
+		{"WithLinkTracking", strconv.Itoa(stats.WithLinkTracking)},
+		{"WithOpenTracking", strconv.Itoa(stats.WithOpenTracking)},
+		{"TotalTrackedLinksSent", strconv.Itoa(stats.TotalTrackedLinksSent)},
+		{"UniqueLinksClicked", strconv.Itoa(stats.UniqueLinksClicked)},
+		{"TotalClicks", strconv.Itoa(stats.TotalClicks)},
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write stats CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// MessageStreamType is the kind of traffic a message stream carries
+type MessageStreamType string
+
+const (
+	MessageStreamTransactional MessageStreamType = "Transactional"
+	MessageStreamBroadcast     MessageStreamType = "Broadcasts"
+	MessageStreamInbound       MessageStreamType = "Inbound"
+)
+
+func (t MessageStreamType) valid() bool {
+	switch t {
+	case MessageStreamTransactional, MessageStreamBroadcast, MessageStreamInbound:
+		return true
+	}
+	return false
+}
+
+// MessageStreamCreate represents the request body for creating a message stream
+type MessageStreamCreate struct {
+	ID                string            `json:"ID"`
+	Name              string            `json:"Name"`
+	Description       string            `json:"Description,omitempty"`
+	MessageStreamType MessageStreamType `json:"MessageStreamType"`
+}
+
+// MessageStream represents a message stream on a server
+type MessageStream struct {
+	ID                string            `json:"ID"`
+	ServerID          int64             `json:"ServerID"`
+	Name              string            `json:"Name"`
+	Description       string            `json:"Description"`
+	MessageStreamType MessageStreamType `json:"MessageStreamType"`
+	CreatedAt         string            `json:"CreatedAt"`
+	UpdatedAt         string            `json:"UpdatedAt"`
+	ArchivedAt        string            `json:"ArchivedAt"`
+}
+
+func (c *Client) CreateMessageStream(stream MessageStreamCreate) (MessageStream, error) {
+	if !stream.MessageStreamType.valid() {
+		return MessageStream{}, fmt.Errorf("%w: %q must be one of %s, %s or %s", ErrInvalidMessageStreamType,
+			stream.MessageStreamType, MessageStreamTransactional, MessageStreamBroadcast, MessageStreamInbound)
+	}
+
+	var messageStream MessageStream
+	if err := c.doRequest("POST", "/message-streams", stream, &messageStream); err != nil {
+		return MessageStream{}, err
+	}
+	return messageStream, nil
+}
+
+func (c *Client) GetMessageStream(ID string) (MessageStream, error) {
+	var messageStream MessageStream
+	if err := c.doRequest("GET", "/message-streams/"+url.PathEscape(ID), nil, &messageStream); err != nil {
+		return MessageStream{}, err
+	}
+	return messageStream, nil
+}
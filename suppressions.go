@@ -0,0 +1,100 @@
+package main
+
+import (
+	"net/mail"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMessageStream       = "outbound"
+	defaultSuppressionCacheTTL = 5 * time.Minute
+)
+
+// SuppressionsResponse represents the response from Postmark API for dumping suppressions
+type SuppressionsResponse struct {
+	Suppressions []Suppression `json:"Suppressions"`
+}
+
+// Suppression represents a recipient that will not receive mail on a message stream
+type Suppression struct {
+	EmailAddress      string `json:"EmailAddress"`
+	SuppressionReason string `json:"SuppressionReason"`
+	Origin            string `json:"Origin"`
+	CreatedAt         string `json:"CreatedAt"`
+}
+
+func (c *Client) GetSuppressions(streamID string) ([]Suppression, error) {
+	var suppressionsResponse SuppressionsResponse
+	if err := c.doRequest("GET", "/message-streams/"+url.PathEscape(streamID)+"/suppressions/dump", nil, &suppressionsResponse); err != nil {
+		return nil, err
+	}
+	return suppressionsResponse.Suppressions, nil
+}
+
+// suppressionCache holds the suppressed addresses of one stream, refreshed after ttl
+type suppressionCache struct {
+	mu        sync.Mutex
+	streamID  string
+	ttl       time.Duration
+	addresses map[string]bool
+	fetchedAt time.Time
+}
+
+func (sc *suppressionCache) load(c *Client) (map[string]bool, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.addresses != nil && time.Since(sc.fetchedAt) < sc.ttl {
+		return sc.addresses, nil
+	}
+
+	suppressions, err := c.GetSuppressions(sc.streamID)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make(map[string]bool, len(suppressions))
+	for _, suppression := range suppressions {
+		addresses[strings.ToLower(suppression.EmailAddress)] = true
+	}
+	sc.addresses = addresses
+	sc.fetchedAt = time.Now()
+	return addresses, nil
+}
+
+// appliesTo reports whether an email is sent on the cached stream.
+func (sc *suppressionCache) appliesTo(email EmailRequest) bool {
+	stream := email.MessageStream
+	if stream == "" {
+		stream = defaultMessageStream
+	}
+	return stream == sc.streamID
+}
+
+// withoutSuppressed removes suppressed addresses from a comma-separated recipient list.
+func withoutSuppressed(list string, suppressed map[string]bool) string {
+	if list == "" {
+		return ""
+	}
+	parsed, err := mail.ParseAddressList(list)
+	if err != nil {
+		return list
+	}
+
+	kept := make([]string, 0, len(parsed))
+	for _, address := range parsed {
+		if suppressed[strings.ToLower(address.Address)] {
+			continue
+		}
+		if address.Name == "" {
+			kept = append(kept, address.Address)
+		} else {
+			kept = append(kept, address.String())
+		}
+	}
+	if len(kept) == len(parsed) {
+		return list
+	}
+	return strings.Join(kept, ", ")
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// LinkTracking controls how Postmark rewrites links for click tracking
+type LinkTracking string
+
+const (
+	LinkTrackingNone        LinkTracking = "None"
+	LinkTrackingHtmlAndText LinkTracking = "HtmlAndText"
+	LinkTrackingHtmlOnly    LinkTracking = "HtmlOnly"
+	LinkTrackingTextOnly    LinkTracking = "TextOnly"
+)
+
+// TemplatedEmailRequest represents an email rendered from a stored template.
+// TrackOpens and TrackLinks are omitted when unset so the template's own
+// tracking configuration applies.
+type TemplatedEmailRequest struct {
+	From          string                 `json:"From"`
+	To            string                 `json:"To"`
+	TemplateID    int64                  `json:"TemplateId,omitempty"`
+	TemplateAlias string                 `json:"TemplateAlias,omitempty"`
+	TemplateModel map[string]interface{} `json:"TemplateModel"`
+	Tag           string                 `json:"Tag,omitempty"`
+	TrackOpens    *bool                  `json:"TrackOpens,omitempty"`
+	TrackLinks    LinkTracking           `json:"TrackLinks,omitempty"`
+	MessageStream string                 `json:"MessageStream,omitempty"`
+
+	// Preview renders the template with TemplateModel through the validate endpoint
+	// and returns the result in EmailResponse.Preview instead of sending anything.
+	Preview bool `json:"-"`
+}
+
+// TemplatedBatchRequest represents the request body for sending templated emails in a batch
+type TemplatedBatchRequest struct {
+	Messages []TemplatedEmailRequest `json:"Messages"`
+}
+
+func (c *Client) prepareTemplatedEmail(email TemplatedEmailRequest) TemplatedEmailRequest {
+	if email.Tag == "" {
+		email.Tag = c.defaultTag
+	}
+	if email.From == "" && c.fromPool != nil {
+		email.From = c.fromPool.pick()
+	}
+	if c.forceNoTracking {
+		email.TrackOpens, email.TrackLinks = noTracking()
+	}
+	return email
+}
+
+func (c *Client) SendTemplatedEmail(email TemplatedEmailRequest) (EmailResponse, error) {
+	email = c.prepareTemplatedEmail(email)
+	if err := c.checkTemplatedEmail(email); err != nil {
+		return EmailResponse{}, err
+	}
+
+	if email.Preview {
+		return c.previewTemplatedEmail(email)
+	}
+
+	var emailResponse EmailResponse
+	if err := c.doRequest("POST", "/email/withTemplate", email, &emailResponse); err != nil {
+		return EmailResponse{}, err
+	}
+	return emailResponse, nil
+}
+
+func (c *Client) previewTemplatedEmail(email TemplatedEmailRequest) (EmailResponse, error) {
+	idOrAlias := email.TemplateAlias
+	if email.TemplateID != 0 {
+		idOrAlias = strconv.FormatInt(email.TemplateID, 10)
+	}
+	template, err := c.GetTemplate(idOrAlias)
+	if err != nil {
+		return EmailResponse{}, err
+	}
+
+	rendered, err := c.renderTemplate(template, email.TemplateModel)
+	if err != nil {
+		return EmailResponse{}, err
+	}
+	return EmailResponse{To: email.To, Message: "Preview only, not sent", Preview: &rendered}, nil
+}
+
+// SendTemplatedEmailBatch sends up to 500 templated emails in one request. Results
+// line up with the input slice; if any message failed a *BatchError lists them by index.
+func (c *Client) SendTemplatedEmailBatch(emails []TemplatedEmailRequest) ([]EmailResponse, error) {
+	batch := TemplatedBatchRequest{Messages: make([]TemplatedEmailRequest, len(emails))}
+	for i, email := range emails {
+		batch.Messages[i] = c.prepareTemplatedEmail(email)
+		if err := c.checkTemplatedEmail(batch.Messages[i]); err != nil {
+			return nil, fmt.Errorf("message #%d: %w", i, err)
+		}
+	}
+
+	var emailResponses []EmailResponse
+	if err := c.doRequest("POST", "/email/batchWithTemplates", batch, &emailResponses); err != nil {
+		return nil, err
+	}
+	emailResponses = withMissingResults(emailResponses, len(batch.Messages))
+	return emailResponses, batchError(emailResponses)
+}
+
+// SendTemplatedEmailToMany sends the same template and model to each recipient as an
+// individual message, splitting the recipients across as many batches as needed.
+// template is either a numeric template ID or an alias.
+func (c *Client) SendTemplatedEmailToMany(from, template string, model map[string]interface{}, recipients []string) ([]EmailResponse, error) {
+	templateID, templateAlias := templateReference(template)
+	emails := make([]TemplatedEmailRequest, len(recipients))
+	for i, recipient := range recipients {
+		emails[i] = TemplatedEmailRequest{
+			From:          from,
+			To:            recipient,
+			TemplateID:    templateID,
+			TemplateAlias: templateAlias,
+			TemplateModel: model,
+		}
+	}
+	return c.sendTemplatedInBatches(emails)
+}
+
+// RecipientModel is one recipient of SendTemplatedBroadcast and the model values that
+// are specific to them
+type RecipientModel struct {
+	To    string
+	Model map[string]interface{}
+}
+
+// SendTemplatedBroadcast sends template to each recipient on the broadcast message
+// stream with ID stream, rendered with base merged with the recipient's own Model.
+// Top-level keys in the recipient's Model replace the same keys in base; nested maps are
+// not merged. template is either a numeric template ID or an alias. The results line up
+// with recipients.
+func (c *Client) SendTemplatedBroadcast(from, template, stream string, base map[string]interface{}, recipients []RecipientModel) ([]EmailResponse, error) {
+	if stream == "" {
+		return nil, fmt.Errorf("%w: a broadcast message stream is required", ErrInvalidEmail)
+	}
+
+	templateID, templateAlias := templateReference(template)
+	emails := make([]TemplatedEmailRequest, len(recipients))
+	for i, recipient := range recipients {
+		model := make(map[string]interface{}, len(base)+len(recipient.Model))
+		for key, value := range base {
+			model[key] = value
+		}
+		for key, value := range recipient.Model {
+			model[key] = value
+		}
+		emails[i] = TemplatedEmailRequest{
+			From:          from,
+			To:            recipient.To,
+			TemplateID:    templateID,
+			TemplateAlias: templateAlias,
+			TemplateModel: model,
+			MessageStream: stream,
+		}
+	}
+	return c.sendTemplatedInBatches(emails)
+}
+
+// sendTemplatedInBatches sends emails in as many batches as needed. The results line
+// up with emails.
+func (c *Client) sendTemplatedInBatches(emails []TemplatedEmailRequest) ([]EmailResponse, error) {
+	emailResponses := make([]EmailResponse, 0, len(emails))
+	for start := 0; start < len(emails); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(emails))
+		batchResponses, err := c.SendTemplatedEmailBatch(emails[start:end])
+		if err != nil && batchResponses == nil {
+			return emailResponses, err
+		}
+		emailResponses = append(emailResponses, batchResponses...)
+	}
+	return emailResponses, batchError(emailResponses)
+}
+
+// templateReference splits a template identifier into a numeric ID or an alias.
+func templateReference(idOrAlias string) (int64, string) {
+	if ID, err := strconv.ParseInt(idOrAlias, 10, 64); err == nil {
+		return ID, ""
+	}
+	return 0, idOrAlias
+}
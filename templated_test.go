@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendTemplatedBroadcast(t *testing.T) {
+	var sent TemplatedBatchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Write([]byte(`[{"ErrorCode":0,"Message":"OK"},{"ErrorCode":0,"Message":"OK"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL))
+	base := map[string]interface{}{"company": "Acme", "name": "customer"}
+	_, err := client.SendTemplatedBroadcast("news@example.com", "monthly-statement", "statements", base, []RecipientModel{
+		{To: "ada@example.com", Model: map[string]interface{}{"name": "Ada"}},
+		{To: "bob@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sent.Messages) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(sent.Messages))
+	}
+	for i, want := range []string{"Ada", "customer"} {
+		message := sent.Messages[i]
+		if message.MessageStream != "statements" || message.TemplateAlias != "monthly-statement" {
+			t.Errorf("message %d stream %q alias %q", i, message.MessageStream, message.TemplateAlias)
+		}
+		if message.TemplateModel["name"] != want || message.TemplateModel["company"] != "Acme" {
+			t.Errorf("message %d model = %v, want name %q and company Acme", i, message.TemplateModel, want)
+		}
+	}
+	if base["name"] != "customer" {
+		t.Errorf("base model was modified: %v", base)
+	}
+}
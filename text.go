@@ -0,0 +1,32 @@
+package main
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlHiddenPattern    = regexp.MustCompile(`(?is)<(script|style|head)[^>]*>.*?</(script|style|head)>`)
+	htmlLineBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6]|table|ul|ol)>`)
+	htmlTagPattern       = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankPattern         = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLinesPattern    = regexp.MustCompile(`\n{3,}`)
+)
+
+// HTMLToText produces a plain-text rendering of an HTML body suitable for TextBody
+func HTMLToText(htmlBody string) string {
+	text := htmlHiddenPattern.ReplaceAllString(htmlBody, "")
+	text = htmlLineBreakPattern.ReplaceAllString(text, "\n")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(blankPattern.ReplaceAllString(line, " "))
+	}
+	text = strings.Join(lines, "\n")
+	text = blankLinesPattern.ReplaceAllString(text, "\n\n")
+
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GetMessageTimeline returns everything that happened to a sent message in
+// chronological order: its submission, the delivery, open, click and bounce
+// events Postmark recorded against it, and any bounces only listed in /bounces.
+func (c *Client) GetMessageTimeline(messageID string) ([]MessageEvent, error) {
+	details, err := c.GetOutboundMessageDetails(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []MessageEvent{{
+		Recipient:  strings.Join(details.Recipients, ", "),
+		Type:       "Submitted",
+		ReceivedAt: details.ReceivedAt,
+	}}
+	seenBounces := make(map[string]bool)
+	for _, event := range details.MessageEvents {
+		if bounceID := event.Details["BounceID"]; bounceID != "" {
+			seenBounces[bounceID] = true
+		}
+		events = append(events, event)
+	}
+
+	bounces, err := c.GetBounces(BounceQuery{Count: maxBouncePageSize, MessageID: messageID})
+	if err != nil {
+		return nil, err
+	}
+	for _, bounce := range bounces {
+		bounceID := strconv.FormatInt(bounce.ID, 10)
+		if seenBounces[bounceID] {
+			continue
+		}
+		events = append(events, MessageEvent{
+			Recipient:  bounce.Email,
+			Type:       "Bounced",
+			ReceivedAt: bounce.BouncedAt,
+			Details:    map[string]string{"BounceID": bounceID, "Summary": bounce.Description},
+		})
+	}
+
+	// Events with a timestamp that does not parse keep their order after all the others.
+	sort.SliceStable(events, func(i, j int) bool {
+		ti, errI := parseTimestamp(events[i].ReceivedAt)
+		tj, errJ := parseTimestamp(events[j].ReceivedAt)
+		return errI == nil && (errJ != nil || ti.Before(tj))
+	})
+	return events, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timestampLayouts are the formats Postmark uses for timestamps, with and without a zone offset.
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"}
+
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", value)
+}
+
+// UnmarshalJSON decodes SubmittedAt into a time.Time. It is left zero when Postmark
+// omits it, as it does for messages that failed.
+func (r *EmailResponse) UnmarshalJSON(data []byte) error {
+	type emailResponse EmailResponse
+	aux := struct {
+		*emailResponse
+		SubmittedAt string `json:"SubmittedAt"`
+	}{emailResponse: (*emailResponse)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.SubmittedAt = time.Time{}
+	if aux.SubmittedAt == "" {
+		return nil
+	}
+	submittedAt, err := parseTimestamp(aux.SubmittedAt)
+	if err != nil {
+		return err
+	}
+	r.SubmittedAt = submittedAt
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// TrackingQuery represents the filters for listing open and click events
+type TrackingQuery struct {
+	Count         int
+	Offset        int
+	Recipient     string
+	Tag           string
+	MessageStream string
+}
+
+func (q TrackingQuery) values() url.Values {
+	values := url.Values{}
+	values.Set("count", strconv.Itoa(q.Count))
+	values.Set("offset", strconv.Itoa(q.Offset))
+	if q.Recipient != "" {
+		values.Set("recipient", q.Recipient)
+	}
+	if q.Tag != "" {
+		values.Set("tag", q.Tag)
+	}
+	if q.MessageStream != "" {
+		values.Set("messagestream", q.MessageStream)
+	}
+	return values
+}
+
+// Agent describes the email client or operating system that triggered an event
+type Agent struct {
+	Name    string `json:"Name"`
+	Company string `json:"Company"`
+	Family  string `json:"Family"`
+}
+
+// Geo describes where an event was triggered from
+type Geo struct {
+	CountryISOCode string `json:"CountryISOCode"`
+	Country        string `json:"Country"`
+	RegionISOCode  string `json:"RegionISOCode"`
+	Region         string `json:"Region"`
+	City           string `json:"City"`
+	Zip            string `json:"Zip"`
+	Coords         string `json:"Coords"`
+	IP             string `json:"IP"`
+}
+
+// OpensResponse represents the response from Postmark API for listing opens
+type OpensResponse struct {
+	TotalCount int         `json:"TotalCount"`
+	Opens      []OpenEvent `json:"Opens"`
+}
+
+// OpenEvent represents a recipient opening a message. FirstOpen is only set on
+// the first open by each recipient, so it can be used to count unique opens.
+type OpenEvent struct {
+	MessageID     string `json:"MessageID"`
+	MessageStream string `json:"MessageStream"`
+	Recipient     string `json:"Recipient"`
+	Tag           string `json:"Tag"`
+	FirstOpen     bool   `json:"FirstOpen"`
+	ReceivedAt    string `json:"ReceivedAt"`
+	Platform      string `json:"Platform"`
+	ReadSeconds   int    `json:"ReadSeconds"`
+	Client        Agent  `json:"Client"`
+	OS            Agent  `json:"OS"`
+	Geo           Geo    `json:"Geo"`
+	UserAgent     string `json:"UserAgent"`
+}
+
+func (c *Client) GetOpens(query TrackingQuery) ([]OpenEvent, error) {
+	var opensResponse OpensResponse
+	if err := c.doRequest("GET", "/messages/outbound/opens?"+query.values().Encode(), nil, &opensResponse); err != nil {
+		return nil, err
+	}
+	return opensResponse.Opens, nil
+}
+
+func (c *Client) GetMessageOpens(messageID string, offset, count int) ([]OpenEvent, error) {
+	path := fmt.Sprintf("/messages/outbound/opens/%s?offset=%d&count=%d", url.PathEscape(messageID), offset, count)
+	var opensResponse OpensResponse
+	if err := c.doRequest("GET", path, nil, &opensResponse); err != nil {
+		return nil, err
+	}
+	return opensResponse.Opens, nil
+}
+
+// ClickLocation is the part of a message a tracked link was clicked in
+type ClickLocation string
+
+const (
+	ClickLocationHTML ClickLocation = "HTML"
+	ClickLocationText ClickLocation = "Text"
+)
+
+// ClicksResponse represents the response from Postmark API for listing clicks
+type ClicksResponse struct {
+	TotalCount int          `json:"TotalCount"`
+	Clicks     []ClickEvent `json:"Clicks"`
+}
+
+// ClickEvent represents a recipient clicking a tracked link. OriginalLink is the
+// URL as written in the message, before Postmark rewrote it for tracking.
+type ClickEvent struct {
+	MessageID     string        `json:"MessageID"`
+	MessageStream string        `json:"MessageStream"`
+	Recipient     string        `json:"Recipient"`
+	Tag           string        `json:"Tag"`
+	OriginalLink  string        `json:"OriginalLink"`
+	ClickLocation ClickLocation `json:"ClickLocation"`
+	ReceivedAt    string        `json:"ReceivedAt"`
+	Platform      string        `json:"Platform"`
+	Client        Agent         `json:"Client"`
+	OS            Agent         `json:"OS"`
+	Geo           Geo           `json:"Geo"`
+	UserAgent     string        `json:"UserAgent"`
+}
+
+func (c *Client) GetClicks(query TrackingQuery) ([]ClickEvent, error) {
+	var clicksResponse ClicksResponse
+	if err := c.doRequest("GET", "/messages/outbound/clicks?"+query.values().Encode(), nil, &clicksResponse); err != nil {
+		return nil, err
+	}
+	return clicksResponse.Clicks, nil
+}
+
+func (c *Client) GetMessageClicks(messageID string, offset, count int) ([]ClickEvent, error) {
+	path := fmt.Sprintf("/messages/outbound/clicks/%s?offset=%d&count=%d", url.PathEscape(messageID), offset, count)
+	var clicksResponse ClicksResponse
+	if err := c.doRequest("GET", path, nil, &clicksResponse); err != nil {
+		return nil, err
+	}
+	return clicksResponse.Clicks, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// defaultValidationConcurrency caps the validations ValidateTemplates runs at once. A
+// lower WithMaxConcurrency limit still applies.
+const defaultValidationConcurrency = 4
+
+// TemplateValidationRequest represents the request body for validating and test-rendering template content
+type TemplateValidationRequest struct {
+	Subject                    string                 `json:"Subject,omitempty"`
+	HtmlBody                   string                 `json:"HtmlBody,omitempty"`
+	TextBody                   string                 `json:"TextBody,omitempty"`
+	TestRenderModel            map[string]interface{} `json:"TestRenderModel,omitempty"`
+	InlineCssForHtmlTestRender bool                   `json:"InlineCssForHtmlTestRender,omitempty"`
+}
+
+// TemplateValidationResult represents the response from Postmark API for template validation
+type TemplateValidationResult struct {
+	AllContentIsValid      bool                    `json:"AllContentIsValid"`
+	Subject                TemplateFieldValidation `json:"Subject"`
+	HtmlBody               TemplateFieldValidation `json:"HtmlBody"`
+	TextBody               TemplateFieldValidation `json:"TextBody"`
+	SuggestedTemplateModel map[string]interface{}  `json:"SuggestedTemplateModel"`
+}
+
+// TemplateFieldValidation represents the validation outcome of a single template field
+type TemplateFieldValidation struct {
+	ContentIsValid   bool              `json:"ContentIsValid"`
+	ValidationErrors []ValidationError `json:"ValidationErrors"`
+	RenderedContent  string            `json:"RenderedContent"`
+}
+
+// ValidationError is a syntax error in a template field. Line and CharacterPosition
+// locate it within the field's content.
+type ValidationError struct {
+	Message           string `json:"Message"`
+	Line              int    `json:"Line"`
+	CharacterPosition int    `json:"CharacterPosition"`
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("line %d, position %d: %s", e.Line, e.CharacterPosition, e.Message)
+}
+
+// RenderedTemplate is a template rendered against one model
+type RenderedTemplate struct {
+	Model    map[string]interface{}
+	Valid    bool
+	Subject  string
+	HtmlBody string
+	TextBody string
+}
+
+func (c *Client) ValidateTemplate(req TemplateValidationRequest) (TemplateValidationResult, error) {
+	var result TemplateValidationResult
+	if err := c.doRequest("POST", "/templates/validate", req, &result); err != nil {
+		return TemplateValidationResult{}, err
+	}
+	return result, nil
+}
+
+// ValidateTemplates validates each request concurrently, at most
+// defaultValidationConcurrency at a time and never more than WithMaxConcurrency allows.
+// The results line up with reqs; a failed validation leaves a zero result, and every
+// failure is reported in the joined error.
+func (c *Client) ValidateTemplates(reqs []TemplateValidationRequest) ([]TemplateValidationResult, error) {
+	results := make([]TemplateValidationResult, len(reqs))
+	errs := make([]error, len(reqs))
+	slots := make(chan struct{}, defaultValidationConcurrency)
+
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result, err := c.ValidateTemplate(req)
+			if err != nil {
+				errs[i] = fmt.Errorf("template %d: %w", i, err)
+				return
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+// RenderTemplateMulti renders a stored template against each model in turn. The
+// results line up with models.
+func (c *Client) RenderTemplateMulti(idOrAlias string, models []map[string]interface{}) ([]RenderedTemplate, error) {
+	template, err := c.GetTemplate(idOrAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make([]RenderedTemplate, 0, len(models))
+	for _, model := range models {
+		renderedTemplate, err := c.renderTemplate(template, model)
+		if err != nil {
+			return rendered, err
+		}
+		rendered = append(rendered, renderedTemplate)
+	}
+	return rendered, nil
+}
+
+func (c *Client) renderTemplate(template *PostmarkTemplateResponse, model map[string]interface{}) (RenderedTemplate, error) {
+	result, err := c.ValidateTemplate(TemplateValidationRequest{
+		Subject:         template.Subject,
+		HtmlBody:        template.HtmlBody,
+		TextBody:        template.TextBody,
+		TestRenderModel: model,
+	})
+	if err != nil {
+		return RenderedTemplate{}, err
+	}
+	return RenderedTemplate{
+		Model:    model,
+		Valid:    result.AllContentIsValid,
+		Subject:  result.Subject.RenderedContent,
+		HtmlBody: result.HtmlBody.RenderedContent,
+		TextBody: result.TextBody.RenderedContent,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+)
+
+// VerifyWebhookAuth reports whether a webhook request carries the expected Basic Auth
+// credentials. Both values are always compared in constant time.
+func VerifyWebhookAuth(r *http.Request, expectedUser, expectedPass string) bool {
+	user, pass, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+	userMatch := subtle.ConstantTimeCompare([]byte(user), []byte(expectedUser))
+	passMatch := subtle.ConstantTimeCompare([]byte(pass), []byte(expectedPass))
+	return userMatch&passMatch == 1
+}
+
+// WebhookEvent represents the fields shared by Postmark's bounce and spam complaint webhooks
+type WebhookEvent struct {
+	RecordType    string `json:"RecordType"`
+	Type          string `json:"Type"`
+	TypeCode      int    `json:"TypeCode"`
+	MessageID     string `json:"MessageID"`
+	MessageStream string `json:"MessageStream"`
+	Email         string `json:"Email"`
+	Inactive      bool   `json:"Inactive"`
+	Description   string `json:"Description"`
+}
+
+// NewWebhookHandler returns a handler for bounce and spam complaint webhooks that calls
+// onSuppress with the recipient and reason ("HardBounce" or "SpamComplaint") for every
+// event that should stop further mail to that address. Other events are acknowledged
+// and ignored.
+func NewWebhookHandler(onSuppress func(email, reason string)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var event WebhookEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+			return
+		}
+
+		switch {
+		case event.RecordType == "Bounce" && event.Type == "HardBounce":
+			onSuppress(event.Email, "HardBounce")
+		case event.RecordType == "SpamComplaint":
+			onSuppress(event.Email, "SpamComplaint")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}